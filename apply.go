@@ -0,0 +1,260 @@
+package gonp
+
+// PatchOptions configures how UniPatchFuzzy locates hunks in a sequence
+type PatchOptions struct {
+	// MaxOffset is how far a hunk may be found relative to where the previous hunk applied, like GNU patch.
+	// Offsets add up over hunks, so the total offset of a hunk may exceed MaxOffset.
+	// A negative MaxOffset is the same as 0.
+	MaxOffset int
+	// Fuzz is how many outer context elements of a hunk may be ignored
+	Fuzz int
+	// Reverse applies hunks backwards, turning b into a
+	Reverse bool
+}
+
+// HunkResult describes where a hunk was applied
+type HunkResult struct {
+	// Offset is the distance from the position recorded in the hunk
+	Offset int
+	// Fuzz is the number of context elements ignored at each end of the hunk
+	Fuzz int
+	// Err is the reason why the hunk is rejected, nil if it is applied
+	Err error
+}
+
+// UniPatchFuzzy applies unified format difference to seq like GNU patch does.
+// Each hunk is searched for within opts.MaxOffset around its recorded position shifted by the offset of the previous hunk,
+// ignoring up to opts.Fuzz outer context elements when it does not match exactly.
+func (diff *Diff[T]) UniPatchFuzzy(seq []T, uniHunks []UniHunk[T], opts PatchOptions) ([]T, []HunkResult, error) {
+	if opts.Reverse {
+		uniHunks = InvertUniHunks(uniHunks)
+	}
+
+	ap := newHunkApplier(seq, diff.cmp)
+	results := make([]HunkResult, 0, len(uniHunks))
+	for i, h := range uniHunks {
+		result, ok := ap.apply(h, opts)
+		if !ok {
+			return []T{}, results, ap.reject(h, i+1, opts)
+		}
+		results = append(results, result)
+	}
+
+	return ap.finish(), results, nil
+}
+
+// UniPatchPartial applies hunks of unified format difference which fit seq like UniPatchFuzzy
+// and collects the rest as rejected, which can be written with FprintUniHunks like a ".rej" file.
+// Rejected hunks are returned as given even if opts.Reverse is set.
+func (diff *Diff[T]) UniPatchPartial(seq []T, uniHunks []UniHunk[T], opts PatchOptions) ([]T, []HunkResult, []UniHunk[T]) {
+	hunks := uniHunks
+	if opts.Reverse {
+		hunks = InvertUniHunks(uniHunks)
+	}
+
+	ap := newHunkApplier(seq, diff.cmp)
+	results := make([]HunkResult, 0, len(hunks))
+	rejected := make([]UniHunk[T], 0)
+	for i, h := range hunks {
+		result, ok := ap.apply(h, opts)
+		if !ok {
+			result.Err = ap.reject(h, i+1, opts)
+			rejected = append(rejected, uniHunks[i])
+		}
+		results = append(results, result)
+	}
+
+	return ap.finish(), results, rejected
+}
+
+// hunkApplier keeps the state of applying hunks to a sequence one by one
+type hunkApplier[T any] struct {
+	seq    []T
+	cmp    func(T, T) int
+	out    []T
+	last   int // length of the prefix of seq already copied to out
+	offset int // offset of the last applied hunk
+}
+
+func newHunkApplier[T any](seq []T, cmp func(T, T) int) *hunkApplier[T] {
+	return &hunkApplier[T]{
+		seq: seq,
+		cmp: cmp,
+		out: make([]T, 0, len(seq)),
+	}
+}
+
+// apply locates h in the rest of sequence and replaces it
+func (ap *hunkApplier[T]) apply(h UniHunk[T], opts PatchOptions) (HunkResult, bool) {
+	older, lead, trail := hunkElems(h)
+	pos, fuzz, off, ok := ap.locate(h, older, lead, trail, opts)
+	if !ok {
+		return HunkResult{}, false
+	}
+
+	top := min(fuzz, lead)
+	bottom := min(fuzz, trail, len(older)-top)
+	ap.replace(h.changes[top:len(h.changes)-bottom], pos)
+	ap.offset += off
+	return HunkResult{Offset: ap.offset, Fuzz: fuzz}, true
+}
+
+// reject returns the reason why h can not be applied
+func (ap *hunkApplier[T]) reject(h UniHunk[T], n int, opts PatchOptions) error {
+	if ap.applied(h, opts) {
+		return &AlreadyAppliedError{Hunk: n}
+	}
+	// report the difference found at the recorded position
+	return ap.verify(h, n, hunkStart(h)+ap.offset)
+}
+
+// applied reports whether h is found in the rest of sequence already applied
+func (ap *hunkApplier[T]) applied(h UniHunk[T], opts PatchOptions) bool {
+	inverted := h.Invert()
+	older, lead, trail := hunkElems(inverted)
+	_, _, _, ok := ap.locate(inverted, older, lead, trail, opts)
+	return ok
+}
+
+// locate searches for elements older of h, which has lead and trail context elements.
+// It returns position of the elements, fuzz and offset relative to the last hunk.
+func (ap *hunkApplier[T]) locate(h UniHunk[T], older []T, lead, trail int, opts PatchOptions) (pos, fuzz, off int, ok bool) {
+	for fuzz := 0; fuzz <= opts.Fuzz; fuzz++ {
+		top := min(fuzz, lead)
+		bottom := min(fuzz, trail, len(older)-top)
+		if fuzz > 0 && top == 0 && bottom == 0 {
+			// nothing more to ignore
+			break
+		}
+
+		pattern := older[top : len(older)-bottom]
+		start := hunkStart(h) + ap.offset + top
+		for _, off := range searchOrder(opts.MaxOffset) {
+			if ap.matches(pattern, start+off) {
+				return start + off, fuzz, off, true
+			}
+		}
+	}
+
+	return 0, 0, 0, false
+}
+
+// checkRange checks that h fits in the rest of sequence at pos.
+// n is 1-origin number of the hunk reported in errors.
+func (ap *hunkApplier[T]) checkRange(h UniHunk[T], n, pos int) error {
+	if pos < ap.last {
+		return &HunkOverlapError{Hunk: n, Pos: pos + 1, Prev: ap.last + 1}
+	}
+	if pos > len(ap.seq) {
+		return &HunkOutOfRangeError{Hunk: n, Index: 0, Pos: pos + 1, Len: len(ap.seq)}
+	}
+
+	p := pos
+	for i, e := range h.changes {
+		if e.typ == SesAdd {
+			continue
+		}
+		if p >= len(ap.seq) {
+			return &HunkOutOfRangeError{Hunk: n, Index: i, Pos: p + 1, Len: len(ap.seq)}
+		}
+		p++
+	}
+
+	return nil
+}
+
+// verify checks that h is found in the rest of sequence at pos
+func (ap *hunkApplier[T]) verify(h UniHunk[T], n, pos int) error {
+	if err := ap.checkRange(h, n, pos); err != nil {
+		return err
+	}
+
+	p := pos
+	for i, e := range h.changes {
+		if e.typ == SesAdd {
+			continue
+		}
+		if ap.cmp(ap.seq[p], e.elem) != 0 {
+			return &ContextMismatchError[T]{Hunk: n, Index: i, Pos: p + 1, Expected: e.elem, Actual: ap.seq[p]}
+		}
+		p++
+	}
+
+	return nil
+}
+
+// replace replaces elements of changes found at pos.
+// Common elements are kept as found in sequence like Patch does.
+func (ap *hunkApplier[T]) replace(changes []SesElem[T], pos int) {
+	ap.out = append(ap.out, ap.seq[ap.last:pos]...)
+	for _, e := range changes {
+		switch e.typ {
+		case SesDelete:
+			pos++
+		case SesAdd:
+			ap.out = append(ap.out, e.elem)
+		case SesCommon:
+			ap.out = append(ap.out, ap.seq[pos])
+			pos++
+		}
+	}
+	ap.last = pos
+}
+
+// matches reports whether pattern is found in the rest of sequence at pos
+func (ap *hunkApplier[T]) matches(pattern []T, pos int) bool {
+	if pos < ap.last || pos+len(pattern) > len(ap.seq) {
+		return false
+	}
+	for i, e := range pattern {
+		if ap.cmp(ap.seq[pos+i], e) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// finish returns patched sequence
+func (ap *hunkApplier[T]) finish() []T {
+	return append(ap.out, ap.seq[ap.last:]...)
+}
+
+// hunkStart returns 0-origin position in a where the hunk begins
+func hunkStart[T any](h UniHunk[T]) int {
+	if h.b == 0 {
+		// an empty range points the line after which elements are added
+		return h.a
+	}
+	return h.a - 1
+}
+
+// hunkElems returns elements a hunk expects to find in sequence,
+// with the number of leading and trailing context elements
+func hunkElems[T any](h UniHunk[T]) (older []T, lead, trail int) {
+	older = make([]T, 0, h.b)
+	for _, e := range h.changes {
+		if e.typ != SesAdd {
+			older = append(older, e.elem)
+		}
+	}
+
+	for lead < len(h.changes) && h.changes[lead].typ == SesCommon {
+		lead++
+	}
+	for trail < len(h.changes)-lead && h.changes[len(h.changes)-1-trail].typ == SesCommon {
+		trail++
+	}
+
+	return older, lead, trail
+}
+
+// searchOrder returns offsets 0, 1, -1, 2, -2, ... up to n, only 0 if n is negative
+func searchOrder(n int) []int {
+	n = max(n, 0)
+	offsets := make([]int, 0, 2*n+1)
+	offsets = append(offsets, 0)
+	for i := 1; i <= n; i++ {
+		offsets = append(offsets, i, -i)
+	}
+	return offsets
+}
@@ -0,0 +1,272 @@
+package gonp
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUniPatchFuzzy(t *testing.T) {
+	a := strings.Split("abcdefghijklmnopqrst", "")
+	b := strings.Split("abcdeFghijklmnopQrst", "")
+	diff := New(a, b)
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	tests := []struct {
+		name     string
+		seq      string
+		opts     PatchOptions
+		expected string
+		results  []HunkResult
+		fail     bool
+	}{
+		{
+			name:     "exact",
+			seq:      "abcdefghijklmnopqrst",
+			expected: "abcdeFghijklmnopQrst",
+			results:  []HunkResult{{}, {}},
+		},
+		{
+			name:     "offset",
+			seq:      "12abcdefghij3klmnopqrst",
+			opts:     PatchOptions{MaxOffset: 3},
+			expected: "12abcdeFghij3klmnopQrst",
+			results:  []HunkResult{{Offset: 2}, {Offset: 3}},
+		},
+		{
+			name:     "cumulative offset",
+			seq:      "12abcdefghij34klmnopqrst",
+			opts:     PatchOptions{MaxOffset: 2},
+			expected: "12abcdeFghij34klmnopQrst",
+			results:  []HunkResult{{Offset: 2}, {Offset: 4}},
+		},
+		{
+			name:     "negative offset",
+			seq:      "abcdefghijklmnopqrst",
+			opts:     PatchOptions{MaxOffset: -1},
+			expected: "abcdeFghijklmnopQrst",
+			results:  []HunkResult{{}, {}},
+		},
+		{
+			name: "negative offset not found",
+			seq:  "1abcdefghijklmnopqrst",
+			opts: PatchOptions{MaxOffset: -1},
+			fail: true,
+		},
+		{
+			name: "offset too large",
+			seq:  "1234abcdefghijklmnopqrst",
+			opts: PatchOptions{MaxOffset: 3},
+			fail: true,
+		},
+		{
+			name:     "fuzz",
+			seq:      "abcXefghiYklmnopqrsZ",
+			opts:     PatchOptions{Fuzz: 2},
+			expected: "abcXeFghiYklmnopQrsZ",
+			results:  []HunkResult{{Fuzz: 2}, {Fuzz: 1}},
+		},
+		{
+			name: "fuzz not allowed",
+			seq:  "abcXefghiYklmnopqrsZ",
+			fail: true,
+		},
+		{
+			name:     "offset and fuzz",
+			seq:      "1abcXefghiYklmnopqrsZ",
+			opts:     PatchOptions{MaxOffset: 1, Fuzz: 2},
+			expected: "1abcXeFghiYklmnopQrsZ",
+			results:  []HunkResult{{Offset: 1, Fuzz: 2}, {Offset: 1, Fuzz: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		patched, results, err := diff.UniPatchFuzzy(strings.Split(tt.seq, ""), uniHunks, tt.opts)
+		if tt.fail {
+			if err == nil {
+				t.Fatalf(":%s: want error, got: %v", tt.name, patched)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf(":%s: unexpected error: %v", tt.name, err)
+		}
+		if actual := strings.Join(patched, ""); actual != tt.expected {
+			t.Fatalf(":%s: want: %s, got: %s", tt.name, tt.expected, actual)
+		}
+		if !slices.Equal(tt.results, results) {
+			t.Fatalf(":%s:results: want: %v, got: %v", tt.name, tt.results, results)
+		}
+	}
+}
+
+func TestUniPatchFuzzyInsertion(t *testing.T) {
+	uniHunks, err := ParseUniHunks(strings.NewReader("@@ -2,0 +3,2 @@\n+x\n+y\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diff := New[string](nil, nil)
+	patched, _, err := diff.UniPatchFuzzy([]string{"a", "b", "c"}, uniHunks, PatchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"a", "b", "x", "y", "c"}
+	if !slices.Equal(expected, patched) {
+		t.Fatalf("want: %v, got: %v", expected, patched)
+	}
+}
+
+func TestUniPatchStrict(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"a", "x", "c", "d"}
+	diff := New(a, b)
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	patched, err := diff.UniPatchStrict(a, uniHunks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(b, patched) {
+		t.Fatalf("want: %v, got: %v", b, patched)
+	}
+
+	_, err = diff.UniPatchStrict([]string{"a", "y", "c", "d"}, uniHunks)
+	var cme *ContextMismatchError[string]
+	if !errors.As(err, &cme) {
+		t.Fatalf("want: ContextMismatchError, got: %v", err)
+	}
+	expected := ContextMismatchError[string]{Hunk: 1, Index: 1, Pos: 2, Expected: "b", Actual: "y"}
+	if *cme != expected {
+		t.Fatalf("want: %+v, got: %+v", expected, *cme)
+	}
+
+	_, err = diff.UniPatchStrict([]string{"a", "b"}, uniHunks)
+	var oore *HunkOutOfRangeError
+	if !errors.As(err, &oore) {
+		t.Fatalf("want: HunkOutOfRangeError, got: %v", err)
+	}
+
+	_, _, err = diff.UniPatchFuzzy([]string{"a", "b", "z", "d"}, uniHunks, PatchOptions{})
+	if !errors.As(err, &cme) || cme.Index != 3 || cme.Actual != "z" {
+		t.Fatalf("want: ContextMismatchError at element #3, got: %v", err)
+	}
+}
+
+func TestUniPatchKeepsContext(t *testing.T) {
+	diff := New([]string{"a", "b", "c"}, []string{"a", "b", "d"})
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	// a context element different from the hunk is kept like Patch does
+	seq := []string{"a", "y", "c"}
+	expected, err := diff.Patch(seq)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	patched, err := diff.UniPatch(seq, uniHunks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(expected, patched) {
+		t.Fatalf("want: %v, got: %v", expected, patched)
+	}
+}
+
+func TestUniPatchKeepsContextEqualByCmp(t *testing.T) {
+	a := []string{"a", "b", "c"}
+	b := []string{"a", "b", "d"}
+	diff := NewCmp(a, b, func(x, y string) int { return strings.Compare(strings.ToLower(x), strings.ToLower(y)) })
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	seq := []string{"A", "B", "c"}
+	expected := []string{"A", "B", "d"}
+	patched, err := diff.UniPatchStrict(seq, uniHunks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(expected, patched) {
+		t.Fatalf("strict: want: %v, got: %v", expected, patched)
+	}
+	patched, _, err = diff.UniPatchFuzzy(seq, uniHunks, PatchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(expected, patched) {
+		t.Fatalf("fuzzy: want: %v, got: %v", expected, patched)
+	}
+}
+
+func TestPatchStrict(t *testing.T) {
+	a := []string{"a", "b", "c"}
+	b := []string{"a", "x", "c"}
+	diff := New(a, b)
+	diff.Compose()
+
+	patched, err := diff.PatchStrict(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(b, patched) {
+		t.Fatalf("want: %v, got: %v", b, patched)
+	}
+
+	_, err = diff.PatchStrict([]string{"a", "b", "d"})
+	var cme *ContextMismatchError[string]
+	if !errors.As(err, &cme) {
+		t.Fatalf("want: ContextMismatchError, got: %v", err)
+	}
+	expected := ContextMismatchError[string]{Index: 3, Pos: 3, Expected: "c", Actual: "d"}
+	if *cme != expected {
+		t.Fatalf("want: %+v, got: %+v", expected, *cme)
+	}
+
+	for _, seq := range [][]string{{"a", "b"}, {"a", "b", "c", "d"}} {
+		if _, err := diff.PatchStrict(seq); err == nil {
+			t.Fatalf("%v: want error", seq)
+		}
+	}
+}
+
+func TestUniPatchPartial(t *testing.T) {
+	a := strings.Split("abcdefghijklmnopqrst", "")
+	b := strings.Split("abcdeFghijklmnopQrst", "")
+	diff := New(a, b)
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	patched, results, rejected := diff.UniPatchPartial(strings.Split("1abcdefghijklmnoXqrst", ""), uniHunks, PatchOptions{MaxOffset: 1})
+	if actual := strings.Join(patched, ""); actual != "1abcdeFghijklmnoXqrst" {
+		t.Fatalf("want: %s, got: %s", "1abcdeFghijklmnoXqrst", actual)
+	}
+	if len(results) != 2 || results[0].Err != nil || results[0].Offset != 1 {
+		t.Fatalf("unexpected result of hunk #1: %+v", results)
+	}
+	var cme *ContextMismatchError[string]
+	if !errors.As(results[1].Err, &cme) || cme.Hunk != 2 || cme.Actual != "X" {
+		t.Fatalf("want: ContextMismatchError for hunk #2, got: %v", results[1].Err)
+	}
+
+	expected := `@@ -14,7 +14,7 @@
+ n
+ o
+ p
+-q
++Q
+ r
+ s
+ t
+`
+	if actual := SprintUniHunks(rejected); actual != expected {
+		t.Fatalf("rejected: want: %v, got: %v", expected, actual)
+	}
+
+	// negative offset searches only the recorded position
+	_, _, rejected = diff.UniPatchPartial(strings.Split("1abcdefghijklmnopqrst", ""), uniHunks, PatchOptions{MaxOffset: -1})
+	if len(rejected) != 2 {
+		t.Fatalf("want: 2 rejected hunks, got: %v", rejected)
+	}
+}
@@ -0,0 +1,195 @@
+package gonp
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ColorMode decides whether difference is colorized
+type ColorMode int
+
+const (
+	// ColorAuto colorizes difference only when it is written to a terminal
+	ColorAuto ColorMode = iota
+	// ColorAlways always colorizes difference
+	ColorAlways
+	// ColorNever never colorizes difference
+	ColorNever
+)
+
+// Palette is a set of ANSI escape sequences used to colorize difference.
+// An empty sequence leaves the corresponding lines uncolored.
+type Palette struct {
+	Delete     string // deleted elements
+	Add        string // added elements
+	Common     string // common elements
+	Hunk       string // "@@ -a,b +c,d @@" of hunk headers
+	FileHeader string // file headers like "diff --git", "---" and "+++"
+	Reset      string // sequence written after colorized text
+}
+
+// DefaultPalette is the palette git uses by default
+var DefaultPalette = Palette{
+	Delete:     "\x1b[31m",
+	Add:        "\x1b[32m",
+	Hunk:       "\x1b[36m",
+	FileHeader: "\x1b[1m",
+	Reset:      "\x1b[m",
+}
+
+// ColorOptions configures colorized difference
+type ColorOptions struct {
+	// Mode decides whether difference is colorized, ColorAuto if 0
+	Mode ColorMode
+	// Palette is a set of colors, DefaultPalette if nil
+	Palette *Palette
+}
+
+// palette returns the palette to colorize difference written to w, or nil for plain output
+func (opts ColorOptions) palette(w io.Writer) *Palette {
+	switch opts.Mode {
+	case ColorNever:
+		return nil
+	case ColorAuto:
+		if !IsTerminal(w) {
+			return nil
+		}
+	}
+	if opts.Palette == nil {
+		return &DefaultPalette
+	}
+	return opts.Palette
+}
+
+// IsTerminal reports whether w is a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// paint wraps s with color, nil palette or empty color leaves s as is
+func (p *Palette) paint(color, s string) string {
+	if p == nil || color == "" || s == "" {
+		return s
+	}
+	return color + s + p.Reset
+}
+
+// sesColor returns the color of elements of typ
+func (p *Palette) sesColor(typ SesType) string {
+	if p == nil {
+		return ""
+	}
+	switch typ {
+	case SesDelete:
+		return p.Delete
+	case SesAdd:
+		return p.Add
+	default:
+		return p.Common
+	}
+}
+
+// sprintSesElem returns a line of e with the prefix of its type
+func sprintSesElem[T any](e SesElem[T], p *Palette) string {
+	var prefix string
+	switch e.typ {
+	case SesDelete:
+		prefix = "-"
+	case SesAdd:
+		prefix = "+"
+	case SesCommon:
+		prefix = " "
+	}
+	return p.paint(p.sesColor(e.typ), prefix+fmt.Sprint(e.elem)) + "\n"
+}
+
+// sprintHunkRange returns range line of uniHunk with "@@ ... @@" colorized
+func sprintHunkRange[T any](uniHunk *UniHunk[T], p *Palette) string {
+	if p == nil {
+		return uniHunk.SprintDiffRange()
+	}
+	line := strings.TrimSuffix(uniHunk.SprintDiffRange(), "\n")
+	rest := ""
+	if i := strings.Index(line[min(2, len(line)):], "@@"); i >= 0 {
+		line, rest = line[:i+4], line[i+4:]
+	}
+	return p.paint(p.Hunk, line) + rest + "\n"
+}
+
+// fprintFileHeader emits lines of text colorized as file header to w
+func fprintFileHeader(w io.Writer, text string, p *Palette) {
+	if p == nil {
+		fmt.Fprint(w, text)
+		return
+	}
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		fmt.Fprintf(w, "%s\n", p.paint(p.FileHeader, scanner.Text()))
+	}
+}
+
+// PrintSesColor prints colorized shortest edit script between a and b
+func (diff *Diff[T]) PrintSesColor(opts ColorOptions) {
+	diff.FprintSesColor(os.Stdout, opts)
+}
+
+// SprintSesColor returns colorized string about shortest edit script between a and b.
+// Since the string is not a terminal, ColorAuto leaves it plain.
+func (diff *Diff[T]) SprintSesColor(opts ColorOptions) string {
+	var buf bytes.Buffer
+	diff.FprintSesColor(&buf, opts)
+	return buf.String()
+}
+
+// FprintSesColor emit about colorized shortest edit script between a and b to w
+func (diff *Diff[T]) FprintSesColor(w io.Writer, opts ColorOptions) {
+	fprintSes(w, diff.ses, opts.palette(w))
+}
+
+// PrintUniHunksColor prints colorized unified format difference between a and b
+func (diff *Diff[T]) PrintUniHunksColor(uniHunks []UniHunk[T], opts ColorOptions) {
+	FprintUniHunksColor(os.Stdout, uniHunks, opts)
+}
+
+// SprintUniHunksColor returns colorized string about unified format difference between a and b.
+// Since the string is not a terminal, ColorAuto leaves it plain.
+func SprintUniHunksColor[T any](uniHunks []UniHunk[T], opts ColorOptions) string {
+	var buf bytes.Buffer
+	FprintUniHunksColor(&buf, uniHunks, opts)
+	return buf.String()
+}
+
+// FprintUniHunksColor emit about colorized unified format difference between a and b to w
+func FprintUniHunksColor[T any](w io.Writer, uniHunks []UniHunk[T], opts ColorOptions) {
+	fprintUniHunks(w, uniHunks, opts.palette(w))
+}
+
+// PrintFileDiffsColor prints colorized multi-file unified format difference
+func PrintFileDiffsColor(fileDiffs []FileDiff, opts ColorOptions) {
+	FprintFileDiffsColor(os.Stdout, fileDiffs, opts)
+}
+
+// SprintFileDiffsColor returns colorized string about multi-file unified format difference.
+// Since the string is not a terminal, ColorAuto leaves it plain.
+func SprintFileDiffsColor(fileDiffs []FileDiff, opts ColorOptions) string {
+	var buf bytes.Buffer
+	FprintFileDiffsColor(&buf, fileDiffs, opts)
+	return buf.String()
+}
+
+// FprintFileDiffsColor emit about colorized multi-file unified format difference to w
+func FprintFileDiffsColor(w io.Writer, fileDiffs []FileDiff, opts ColorOptions) {
+	fprintFileDiffs(w, fileDiffs, opts.palette(w))
+}
@@ -0,0 +1,99 @@
+package gonp
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestDiffSprintSesColor(t *testing.T) {
+	diff := New([]string{"a", "b", "c"}, []string{"a", "b", "d"})
+	diff.Compose()
+
+	tests := []struct {
+		name     string
+		opts     ColorOptions
+		expected string
+	}{
+		{
+			name:     "always",
+			opts:     ColorOptions{Mode: ColorAlways},
+			expected: " a\n b\n\x1b[31m-c\x1b[m\n\x1b[32m+d\x1b[m\n",
+		},
+		{
+			name:     "never",
+			opts:     ColorOptions{Mode: ColorNever},
+			expected: " a\n b\n-c\n+d\n",
+		},
+		{
+			name:     "auto",
+			opts:     ColorOptions{},
+			expected: " a\n b\n-c\n+d\n",
+		},
+		{
+			name:     "palette",
+			opts:     ColorOptions{Mode: ColorAlways, Palette: &Palette{Add: "<", Common: "=", Reset: ">"}},
+			expected: "= a>\n= b>\n-c\n<+d>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		if actual := diff.SprintSesColor(tt.opts); actual != tt.expected {
+			t.Fatalf(":%s: want: %q, actual: %q", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestSprintUniHunksColor(t *testing.T) {
+	a := []string{"a", "b", "c"}
+	b := []string{"a", "B", "c"}
+	diff := New(a, b)
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+	uniHunks[0].section = "func f()"
+
+	actual := SprintUniHunksColor(uniHunks, ColorOptions{Mode: ColorAlways})
+	expected := "\x1b[36m@@ -1,3 +1,3 @@\x1b[m func f()\n" +
+		" a\n" +
+		"\x1b[31m-b\x1b[m\n" +
+		"\x1b[32m+B\x1b[m\n" +
+		" c\n"
+	if actual != expected {
+		t.Fatalf("want: %q, actual: %q", expected, actual)
+	}
+	if actual := SprintUniHunksColor(uniHunks, ColorOptions{Mode: ColorNever}); actual != SprintUniHunks(uniHunks) {
+		t.Fatalf("want: %q, actual: %q", SprintUniHunks(uniHunks), actual)
+	}
+}
+
+func TestSprintFileDiffsColor(t *testing.T) {
+	diff := New([]string{"a"}, []string{"b"})
+	diff.Compose()
+	fileDiffs := []FileDiff{NewGitFileDiff("f", "f", diff.UnifiedHunks())}
+
+	actual := SprintFileDiffsColor(fileDiffs, ColorOptions{Mode: ColorAlways})
+	expected := "\x1b[1mdiff --git a/f b/f\x1b[m\n" +
+		"\x1b[1m--- a/f\x1b[m\n" +
+		"\x1b[1m+++ b/f\x1b[m\n" +
+		"\x1b[36m@@ -1,1 +1,1 @@\x1b[m\n" +
+		"\x1b[31m-a\x1b[m\n" +
+		"\x1b[32m+b\x1b[m\n"
+	if actual != expected {
+		t.Fatalf("want: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Fatal("buffer is not a terminal")
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "gonp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if IsTerminal(f) {
+		t.Fatal("regular file is not a terminal")
+	}
+}
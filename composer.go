@@ -0,0 +1,68 @@
+package gonp
+
+// Composer composes edit script between a and b.
+// Diff configured with SetComposer builds SES, LCS and the others from the edit script.
+type Composer[T any] interface {
+	// EditScript returns types of edits turning a into b in order.
+	// SesCommon takes an element of both a and b which are equal by cmp,
+	// SesDelete takes an element of a and SesAdd takes an element of b.
+	EditScript(a, b []T, cmp func(T, T) int) []SesType
+}
+
+// ComposerFunc is an adapter to use a function as Composer
+type ComposerFunc[T any] func(a, b []T, cmp func(T, T) int) []SesType
+
+// EditScript calls f(a, b, cmp)
+func (f ComposerFunc[T]) EditScript(a, b []T, cmp func(T, T) int) []SesType {
+	return f(a, b, cmp)
+}
+
+// algorithmComposer is Composer with a built-in algorithm
+type algorithmComposer[T any] struct {
+	algorithm Algorithm
+}
+
+// NewComposer returns Composer with a built-in algorithm
+func NewComposer[T any](algorithm Algorithm) Composer[T] {
+	return algorithmComposer[T]{algorithm: algorithm}
+}
+
+// EditScript composes edit script with the algorithm
+func (c algorithmComposer[T]) EditScript(a, b []T, cmp func(T, T) int) []SesType {
+	diff := NewCmp(a, b, cmp).SetAlgorithm(c.algorithm)
+	diff.Compose()
+	script := make([]SesType, len(diff.ses))
+	for i, e := range diff.ses {
+		script[i] = e.typ
+	}
+	return script
+}
+
+// composeWith composes diff between a and b with edit script by c.
+// It fails with EditScriptError if the edit script does not turn a into b.
+func (diff *Diff[T]) composeWith(c Composer[T]) error {
+	a, b := diff.a, diff.b
+	if diff.reverse {
+		a, b = b, a
+	}
+
+	x, y := 0, 0
+	for i, typ := range c.EditScript(a, b, diff.cmp) {
+		switch {
+		case typ == SesCommon && x < len(a) && y < len(b) && diff.cmp(a[x], b[y]) == 0:
+			x++
+			y++
+		case typ == SesDelete && x < len(a):
+			x++
+		case typ == SesAdd && y < len(b):
+			y++
+		default:
+			return &EditScriptError{Edit: i + 1, Type: typ, A: x, B: y, ALen: len(a), BLen: len(b)}
+		}
+		diff.appendEdit(typ, x-1, y-1)
+	}
+	if x != len(a) || y != len(b) {
+		return &EditScriptError{A: x, B: y, ALen: len(a), BLen: len(b)}
+	}
+	return nil
+}
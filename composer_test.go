@@ -0,0 +1,120 @@
+package gonp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiffSetComposer(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{a: "abcdef", b: "dacfea"},
+		{a: "acbdeacbed", b: "acebdabbabed"},
+		{a: "acebdabbabed", b: "acbdeacbed"},
+		{a: "", b: "abc"},
+		{a: "abc", b: ""},
+	}
+
+	for _, tt := range tests {
+		for _, algorithm := range []Algorithm{AlgorithmONP, AlgorithmLinear, AlgorithmPatience, AlgorithmHistogram} {
+			expected := New([]rune(tt.a), []rune(tt.b)).SetAlgorithm(algorithm)
+			expected.Compose()
+			actual := New([]rune(tt.a), []rune(tt.b)).SetComposer(NewComposer[rune](algorithm))
+			actual.Compose()
+
+			if !reflect.DeepEqual(actual.Ses(), expected.Ses()) {
+				t.Fatalf(":%s,%s,%d: want: %v, actual: %v", tt.a, tt.b, algorithm, expected.Ses(), actual.Ses())
+			}
+			if actual.EditDistance() != expected.EditDistance() || string(actual.Lcs()) != string(expected.Lcs()) {
+				t.Fatalf(":%s,%s,%d: want: %v, actual: %v", tt.a, tt.b, algorithm, string(expected.Lcs()), string(actual.Lcs()))
+			}
+		}
+	}
+}
+
+func TestDiffSetComposerFunc(t *testing.T) {
+	// replaces all elements regardless of equality
+	replace := ComposerFunc[string](func(a, b []string, cmp func(string, string) int) []SesType {
+		script := make([]SesType, 0, len(a)+len(b))
+		for range a {
+			script = append(script, SesDelete)
+		}
+		for range b {
+			script = append(script, SesAdd)
+		}
+		return script
+	})
+
+	a := []string{"a", "b", "c"}
+	b := []string{"a", "d"}
+	diff := New(a, b).SetComposer(replace)
+	diff.Compose()
+	if err := diff.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	checkSes(t, a, b, diff.Ses())
+	if diff.EditDistance() != 5 || len(diff.Lcs()) != 0 {
+		t.Fatalf("want: %v, actual: %v", 5, diff.EditDistance())
+	}
+	expected := `@@ -1,3 +1,2 @@
+-a
+-b
+-c
++a
++d
+`
+	if actual := SprintUniHunks(diff.UnifiedHunks()); actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+	patched, err := diff.Patch(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(patched, b) {
+		t.Fatalf("want: %v, actual: %v", b, patched)
+	}
+}
+
+func TestDiffSetComposerInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		script []SesType
+		edit   int
+	}{
+		{name: "not equal", script: []SesType{SesCommon, SesCommon}, edit: 2},
+		{name: "too many", script: []SesType{SesCommon, SesDelete, SesAdd, SesAdd, SesAdd}, edit: 4},
+		{name: "too few", script: []SesType{SesCommon}, edit: 0},
+		{name: "nil", script: nil, edit: 0},
+	}
+
+	for _, tt := range tests {
+		composer := ComposerFunc[rune](func(a, b []rune, cmp func(rune, rune) int) []SesType { return tt.script })
+		diff := New([]rune("ab"), []rune("ac")).SetComposer(composer)
+		diff.Compose()
+		var se *EditScriptError
+		if !errors.As(diff.Err(), &se) {
+			t.Fatalf(":%s: want EditScriptError, got: %v", tt.name, diff.Err())
+		}
+		if se.Edit != tt.edit {
+			t.Fatalf(":%s:edit: want: %d, got: %d (%v)", tt.name, tt.edit, se.Edit, se)
+		}
+		if len(diff.Ses()) != 0 {
+			t.Fatalf(":%s: want no SES, got: %v", tt.name, diff.Ses())
+		}
+
+		a := []rune("ab")
+		for _, patch := range []func([]rune) ([]rune, error){
+			diff.Patch,
+			diff.PatchStrict,
+			diff.ReversePatch,
+			func(seq []rune) ([]rune, error) { return diff.UniPatch(seq, nil) },
+		} {
+			if _, err := patch(a); !errors.As(err, &se) {
+				t.Fatalf(":%s: want EditScriptError from patch, got: %v", tt.name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package gonp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ContextHunk is an element of context format difference
+type ContextHunk[T Elem] struct {
+	a, b, c, d int // start and length of the ranges in a and b
+	changes    []SesElem[T]
+}
+
+// GetChanges is getter of changes in ContextHunk
+func (contextHunk *ContextHunk[T]) GetChanges() []SesElem[T] {
+	return contextHunk.changes
+}
+
+// GetRange is getter of range of ContextHunk
+func (contextHunk *ContextHunk[T]) GetRange() HunkRange {
+	return HunkRange{OldStart: contextHunk.a, OldLines: contextHunk.b, NewStart: contextHunk.c, NewLines: contextHunk.d}
+}
+
+// SprintOldRange returns formatted string represents the range in a
+func (contextHunk *ContextHunk[T]) SprintOldRange() string {
+	return fmt.Sprintf("*** %s ****\n", contextRange(contextHunk.a, contextHunk.b))
+}
+
+// SprintNewRange returns formatted string represents the range in b
+func (contextHunk *ContextHunk[T]) SprintNewRange() string {
+	return fmt.Sprintf("--- %s ----\n", contextRange(contextHunk.c, contextHunk.d))
+}
+
+// contextRange formats range as the first and the last line like GNU diff
+func contextRange(start, length int) string {
+	if length <= 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, start+length-1)
+}
+
+// ContextHunks composes context format difference between a and b.
+// Hunks are grouped in the same way as UnifiedHunks does.
+func (diff *Diff[T]) ContextHunks() []ContextHunk[T] {
+	uniHunks := diff.UnifiedHunks()
+	contextHunks := make([]ContextHunk[T], 0, len(uniHunks))
+	for _, h := range uniHunks {
+		contextHunks = append(contextHunks, ContextHunk[T]{
+			a: h.a, b: h.b, c: h.c, d: h.d,
+			changes: h.changes,
+		})
+	}
+	return contextHunks
+}
+
+// PrintContextHunks prints context format difference between a and b
+func (diff *Diff[T]) PrintContextHunks(contextHunks []ContextHunk[T]) {
+	fmt.Print(SprintContextHunks(contextHunks))
+}
+
+// SprintContextHunks returns string about context format difference between a and b
+func SprintContextHunks[T any](contextHunks []ContextHunk[T]) string {
+	var buf bytes.Buffer
+	FprintContextHunks(&buf, contextHunks)
+	return buf.String()
+}
+
+// FprintContextHunks emit about context format difference between a and b to w
+func FprintContextHunks[T any](w io.Writer, contextHunks []ContextHunk[T]) {
+	for _, contextHunk := range contextHunks {
+		changed := changedRuns(contextHunk.changes)
+
+		fmt.Fprint(w, "***************\n")
+		fmt.Fprint(w, contextHunk.SprintOldRange())
+		if hasType(contextHunk.changes, SesDelete) {
+			for i, e := range contextHunk.changes {
+				switch {
+				case e.typ == SesCommon:
+					fmt.Fprintf(w, "  %v\n", e.elem)
+				case e.typ == SesDelete && changed[i]:
+					fmt.Fprintf(w, "! %v\n", e.elem)
+				case e.typ == SesDelete:
+					fmt.Fprintf(w, "- %v\n", e.elem)
+				}
+			}
+		}
+
+		fmt.Fprint(w, contextHunk.SprintNewRange())
+		if hasType(contextHunk.changes, SesAdd) {
+			for i, e := range contextHunk.changes {
+				switch {
+				case e.typ == SesCommon:
+					fmt.Fprintf(w, "  %v\n", e.elem)
+				case e.typ == SesAdd && changed[i]:
+					fmt.Fprintf(w, "! %v\n", e.elem)
+				case e.typ == SesAdd:
+					fmt.Fprintf(w, "+ %v\n", e.elem)
+				}
+			}
+		}
+	}
+}
+
+// changedRuns reports for each element whether it belongs to a run of changes
+// having both deleted and added elements
+func changedRuns[T any](changes []SesElem[T]) []bool {
+	changed := make([]bool, len(changes))
+	for i := 0; i < len(changes); {
+		if changes[i].typ == SesCommon {
+			i++
+			continue
+		}
+
+		j := i
+		var del, add bool
+		for ; j < len(changes) && changes[j].typ != SesCommon; j++ {
+			del = del || changes[j].typ == SesDelete
+			add = add || changes[j].typ == SesAdd
+		}
+		for k := i; k < j; k++ {
+			changed[k] = del && add
+		}
+		i = j
+	}
+	return changed
+}
+
+func hasType[T any](changes []SesElem[T], typ SesType) bool {
+	for _, e := range changes {
+		if e.typ == typ {
+			return true
+		}
+	}
+	return false
+}
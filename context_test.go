@@ -0,0 +1,72 @@
+package gonp
+
+import (
+	"testing"
+)
+
+func TestDiffSprintContextHunks(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           []string
+		b           []string
+		contextSize int
+		expected    string
+	}{
+		{
+			name:        "change",
+			a:           []string{"a", "b", "c"},
+			b:           []string{"a", "1", "c"},
+			contextSize: DefaultContextSize,
+			expected: `***************
+*** 1,3 ****
+  a
+! b
+  c
+--- 1,3 ----
+  a
+! 1
+  c
+`,
+		},
+		{
+			name:        "add and delete",
+			a:           []string{"a", "b", "c", "d", "e", "f", "g"},
+			b:           []string{"a", "x", "b", "c", "d", "e", "g"},
+			contextSize: 1,
+			expected: `***************
+*** 1,2 ****
+--- 1,3 ----
+  a
++ x
+  b
+***************
+*** 5,7 ****
+  e
+- f
+  g
+--- 6,7 ----
+`,
+		},
+		{
+			name:        "from empty",
+			a:           []string{},
+			b:           []string{"a"},
+			contextSize: DefaultContextSize,
+			expected: `***************
+*** 0 ****
+--- 1 ----
++ a
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		diff := New(tt.a, tt.b)
+		diff.SetContextSize(tt.contextSize)
+		diff.Compose()
+		actual := SprintContextHunks(diff.ContextHunks())
+		if actual != tt.expected {
+			t.Fatalf(":%s: want: %v, actual: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
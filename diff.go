@@ -27,6 +27,28 @@ const (
 	DefaultRouteSize = 2000000
 )
 
+// Algorithm is an algorithm to compose difference
+type Algorithm int
+
+const (
+	// AlgorithmONP is "An O(NP) Sequence Comparison Algorithm" by Wu, Manber and Myers.
+	// It is the default and memory for routes is limited by SetRouteSize,
+	// so that SES may not be minimal when the limit is exceeded.
+	AlgorithmONP Algorithm = iota
+	// AlgorithmLinear is the linear space refinement of "An O(ND) Difference Algorithm" by Myers,
+	// which divides edit graph at the middle snake recursively.
+	// It yields minimal SES with memory proportional to len(a)+len(b).
+	AlgorithmLinear
+	// AlgorithmPatience is patience diff, which aligns elements occurring exactly once in both a and b first
+	// and composes the rest between them with AlgorithmONP.
+	// SES may not be minimal, but is often easier to read for source code like "git diff --patience".
+	AlgorithmPatience
+	// AlgorithmHistogram is histogram diff, which splits a and b at the common part
+	// containing the element occurring least in a and composes small parts with AlgorithmONP.
+	// It is similar to "git diff --histogram" and uses the key function set by SetKey if any.
+	AlgorithmHistogram
+)
+
 // Point is coordinate in edit graph
 type Point struct{ x, y int }
 
@@ -65,9 +87,23 @@ func (e *SesElem[T]) GetElem() T { return e.elem }
 // GetType is getter of manipulation type of SES
 func (e *SesElem[T]) GetType() SesType { return e.typ }
 
+// GetAIdx is getter of 1-origin index of element in a, 0 if element is not in a
+func (e *SesElem[T]) GetAIdx() int { return e.aIdx }
+
+// GetBIdx is getter of 1-origin index of element in b, 0 if element is not in b
+func (e *SesElem[T]) GetBIdx() int { return e.bIdx }
+
+// NewSesElem returns element of SES.
+// aIdx and bIdx are 1-origin indices of elem in a and b, 0 if elem is not in them.
+func NewSesElem[T any](elem T, typ SesType, aIdx, bIdx int) SesElem[T] {
+	return SesElem[T]{elem: elem, typ: typ, aIdx: aIdx, bIdx: bIdx}
+}
+
 // Diff is context for calculating difference between a and b
 type Diff[T Elem] struct {
 	a, b           []T
+	// seqA and seqB are a and b as given, while Compose may narrow a and b
+	seqA, seqB     []T
 	aLen, bLen     int
 	ox, oy         int
 	ed             int
@@ -80,10 +116,16 @@ type Diff[T Elem] struct {
 	pointWithRoute []PointWithRoute
 	contextSize    int
 	routeSize      int
+	algorithm      Algorithm
 	cmp            func(T, T) int
+	key            func(T) any
+	composer       Composer[T]
+	err            error
 }
 
-func New[T cmp.Ordered](a, b []T) *Diff[T] { return NewCmp(a, b, cmp.Compare) }
+func New[T cmp.Ordered](a, b []T) *Diff[T] {
+	return NewCmp(a, b, cmp.Compare).SetKey(func(e T) any { return e })
+}
 
 // NewCmp is initializer of Diff
 func NewCmp[T any](a, b []T, cmp func(T, T) int) *Diff[T] {
@@ -96,6 +138,8 @@ func NewCmp[T any](a, b []T, cmp func(T, T) int) *Diff[T] {
 	return &Diff[T]{
 		a:           a,
 		b:           b,
+		seqA:        a,
+		seqB:        b,
 		aLen:        len(a),
 		bLen:        len(b),
 		ed:          0,
@@ -116,12 +160,28 @@ func (d *Diff[T]) SetContextSize(n int) *Diff[T] { d.contextSize = n; return d }
 // SetRouteSize sets the context size of unified format difference
 func (d *Diff[T]) SetRouteSize(n int) *Diff[T] { d.routeSize = n; return d }
 
+// SetAlgorithm sets the algorithm to compose difference
+func (d *Diff[T]) SetAlgorithm(algorithm Algorithm) *Diff[T] { d.algorithm = algorithm; return d }
+
+// SetComposer sets Composer to compose edit script instead of the algorithm set by SetAlgorithm.
+// Compose fails with EditScriptError reported by Err if the edit script does not turn a into b.
+func (d *Diff[T]) SetComposer(composer Composer[T]) *Diff[T] { d.composer = composer; return d }
+
+// SetKey sets the function returning a comparable key of element, which is equal for equal elements.
+// AlgorithmHistogram finds equal elements with it instead of sorting them with cmp.
+func (d *Diff[T]) SetKey(key func(T) any) *Diff[T] { d.key = key; return d }
+
 // EditDistance returns edit distance between a and b
 func (d *Diff[T]) EditDistance() int { return d.ed }
 
 // Lcs returns LCS (Longest Common Subsequence) between a and b
 func (diff *Diff[T]) Lcs() []T { return diff.lcs }
 
+// Err returns the error of Compose, which fails only when Composer returns an invalid edit script.
+// Until Err returns nil, EditDistance, Lcs, Ses, UnifiedHunks and the others are meaningless,
+// and Patch, PatchStrict, ReversePatch and UniPatch return the error.
+func (diff *Diff[T]) Err() error { return diff.err }
+
 // Ses return SES (Shortest Edit Script) between a and b
 func (diff *Diff[T]) Ses() []SesElem[T] {
 	return diff.ses
@@ -141,20 +201,38 @@ func (diff *Diff[T]) SprintSes() string {
 
 // FprintSes emit about shortest edit script between a and b to w
 func (diff *Diff[T]) FprintSes(w io.Writer) {
-	for _, e := range diff.ses {
-		switch e.typ {
-		case SesDelete:
-			fmt.Fprintf(w, "-%v\n", e.elem)
-		case SesAdd:
-			fmt.Fprintf(w, "+%v\n", e.elem)
-		case SesCommon:
-			fmt.Fprintf(w, " %v\n", e.elem)
-		}
+	fprintSes(w, diff.ses, nil)
+}
+
+func fprintSes[T any](w io.Writer, ses []SesElem[T], p *Palette) {
+	for _, e := range ses {
+		fmt.Fprint(w, sprintSesElem(e, p))
 	}
 }
 
 // Compose composes diff between a and b
 func (diff *Diff[T]) Compose() {
+	if diff.composer != nil {
+		if err := diff.composeWith(diff.composer); err != nil {
+			// leave no partial result
+			diff.ses, diff.lcs, diff.ed = nil, nil, 0
+			diff.err = err
+		}
+		return
+	}
+
+	switch diff.algorithm {
+	case AlgorithmLinear:
+		diff.composeLinear()
+		return
+	case AlgorithmPatience:
+		diff.composePatience()
+		return
+	case AlgorithmHistogram:
+		diff.composeHistogram()
+		return
+	}
+
 ONP:
 	fp := make([]int, diff.aLen+diff.bLen+3)
 	diff.path = make([]int, diff.aLen+diff.bLen+3)
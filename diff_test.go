@@ -2,7 +2,9 @@ package gonp
 
 import (
 	"cmp"
+	"reflect"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -517,6 +519,34 @@ func TestDiffSprintUniHunks(t *testing.T) {
 -b
 +1
  c
+`
+	if actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+
+	// no hunk of context only follows the last change
+	diff = New(strings.Split("abcdefgh", ""), strings.Split("xbcdefgh", ""))
+	diff.Compose()
+	actual = SprintUniHunks(diff.UnifiedHunks())
+	expected = `@@ -1,4 +1,4 @@
+-a
++x
+ b
+ c
+ d
+`
+	if actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+
+	// hunks without context
+	diff = New(strings.Split("xabcdefgh", ""), strings.Split("abcdefghy", ""))
+	diff.Compose()
+	actual = SprintUniHunks(diff.SetContextSize(0).UnifiedHunks())
+	expected = `@@ -1,1 +0,0 @@
+-x
+@@ -9,0 +9,1 @@
++y
 `
 	if actual != expected {
 		t.Fatalf("want: %v, actual: %v", expected, actual)
@@ -554,3 +584,49 @@ func BenchmarkStringUnifiledHunks(b *testing.B) {
 		_ = diff.UnifiedHunks()
 	}
 }
+
+func TestSesElemIndex(t *testing.T) {
+	diff := New([]rune("abc"), []rune("abd"))
+	diff.Compose()
+
+	expected := [][2]int{{1, 1}, {2, 2}, {3, 0}, {0, 3}}
+	for i, e := range diff.Ses() {
+		if actual := [2]int{e.GetAIdx(), e.GetBIdx()}; actual != expected[i] {
+			t.Fatalf(":%d: want: %v, actual: %v", i, expected[i], actual)
+		}
+	}
+}
+
+func TestNewUniHunk(t *testing.T) {
+	diff := New([]rune("abc"), []rune("abd"))
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	expected := HunkRange{OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3}
+	if actual := uniHunks[0].GetRange(); actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+
+	changes := []SesElem[rune]{
+		NewSesElem('a', SesCommon, 1, 1),
+		NewSesElem('b', SesCommon, 2, 2),
+		NewSesElem('c', SesDelete, 3, 0),
+		NewSesElem('d', SesAdd, 0, 3),
+	}
+	uniHunk, err := NewUniHunk(expected, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(uniHunk, uniHunks[0]) {
+		t.Fatalf("want: %v, actual: %v", uniHunks[0], uniHunk)
+	}
+
+	if _, err := NewUniHunk(HunkRange{OldStart: 1, OldLines: 2, NewStart: 1, NewLines: 3}, changes); err == nil {
+		t.Fatal("error is expected")
+	}
+
+	changes[3] = NewSesElem('d', SesAdd, 4, 3)
+	if _, err := NewUniHunk(expected, changes); err == nil {
+		t.Fatal("error is expected for added element in a")
+	}
+}
@@ -0,0 +1,105 @@
+package gonp
+
+import (
+	"fmt"
+)
+
+// ContextMismatchError is returned when an element to keep or to delete
+// is different from the one found in the patched sequence
+type ContextMismatchError[T any] struct {
+	// Hunk is 1-origin number of the hunk, 0 when SES is applied
+	Hunk int
+	// Index is the index of the element in changes of the hunk or in SES
+	Index int
+	// Pos is 1-origin position in the patched sequence
+	Pos      int
+	Expected T
+	Actual   T
+}
+
+func (e *ContextMismatchError[T]) Error() string {
+	if e.Hunk == 0 {
+		return fmt.Sprintf("element #%d at %d: expected %v, but got %v", e.Index, e.Pos, e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("hunk #%d, element #%d at %d: expected %v, but got %v", e.Hunk, e.Index, e.Pos, e.Expected, e.Actual)
+}
+
+// EmptyPatchError is returned when a difference is applied without any hunk
+type EmptyPatchError struct{}
+
+func (e *EmptyPatchError) Error() string {
+	return "empty patch"
+}
+
+// HunkOutOfRangeError is returned when an element of a hunk or SES
+// points beyond the end of the patched sequence
+type HunkOutOfRangeError struct {
+	// Hunk is 1-origin number of the hunk, 0 when SES is applied
+	Hunk int
+	// Index is the index of the element in changes of the hunk or in SES
+	Index int
+	// Pos is 1-origin position where the element is expected
+	Pos int
+	// Len is the length of the patched sequence
+	Len int
+}
+
+func (e *HunkOutOfRangeError) Error() string {
+	if e.Hunk == 0 {
+		return fmt.Sprintf("element #%d at %d is out of range of sequence with %d elements", e.Index, e.Pos, e.Len)
+	}
+	return fmt.Sprintf("hunk #%d, element #%d at %d is out of range of sequence with %d elements", e.Hunk, e.Index, e.Pos, e.Len)
+}
+
+// HunkOverlapError is returned when a hunk begins before the end of the previous one
+type HunkOverlapError struct {
+	// Hunk is 1-origin number of the hunk
+	Hunk int
+	// Pos is 1-origin position where the hunk begins
+	Pos int
+	// Prev is 1-origin position following the previous hunk
+	Prev int
+}
+
+func (e *HunkOverlapError) Error() string {
+	return fmt.Sprintf("hunk #%d at %d overlaps the previous hunk ending before %d", e.Hunk, e.Pos, e.Prev)
+}
+
+// LengthMismatchError is returned when SES does not cover the whole patched sequence
+type LengthMismatchError struct {
+	Expected int
+	Actual   int
+}
+
+func (e *LengthMismatchError) Error() string {
+	return fmt.Sprintf("expected sequence with %d elements, but got %d", e.Expected, e.Actual)
+}
+
+// AlreadyAppliedError is returned when a hunk can not be applied,
+// but it is found applied already, or the patch is reversed
+type AlreadyAppliedError struct {
+	// Hunk is 1-origin number of the hunk
+	Hunk int
+}
+
+func (e *AlreadyAppliedError) Error() string {
+	return fmt.Sprintf("hunk #%d is already applied or reversed", e.Hunk)
+}
+
+// EditScriptError is returned when edit script of Composer does not turn a into b
+type EditScriptError struct {
+	// Edit is 1-origin number of the invalid edit, 0 when edit script ends too early
+	Edit int
+	Type SesType
+	// A and B are the numbers of elements of a and b taken before the edit
+	A, B int
+	// ALen and BLen are the numbers of elements of a and b
+	ALen, BLen int
+}
+
+func (e *EditScriptError) Error() string {
+	if e.Edit == 0 {
+		return fmt.Sprintf("edit script ends at %d of %d elements of a and %d of %d elements of b", e.A, e.ALen, e.B, e.BLen)
+	}
+	return fmt.Sprintf("edit #%d (%d) is invalid at %d of a and %d of b", e.Edit, e.Type, e.A, e.B)
+}
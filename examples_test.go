@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"time"
 	"unicode/utf8"
 
 	"github.com/quenbyako/gonp"
@@ -40,7 +39,10 @@ func ExampleDiff_Patch_filePatch() {
 	diff := gonp.New(a, b)
 	diff.Compose()
 
-	patchedSeq := diff.Patch(a)
+	patchedSeq, err := diff.Patch(a)
+	if err != nil {
+		log.Fatal(err)
+	}
 	fmt.Printf("success:%v, applying SES between '%s' and '%s'\n", equalsStringSlice(b, patchedSeq), f1, f2)
 
 	uniPatchedSeq, err := diff.UniPatch(a, diff.UnifiedHunks())
@@ -113,7 +115,10 @@ func ExampleDiff_UniPatch_strPatch() {
 	diff := gonp.New(a, b)
 	diff.Compose()
 
-	patchedSeq := diff.Patch(a)
+	patchedSeq, err := diff.Patch(a)
+	if err != nil {
+		log.Fatal(err)
+	}
 	fmt.Printf("success:%v, applying SES between '%s' and '%s' to '%s' is '%s'\n",
 		string(b) == string(patchedSeq),
 		string(a), string(b),
@@ -153,7 +158,11 @@ func ExampleDiff_PrintUniHunks_uniFileDiff() {
 		log.Fatalf("%s: %s", f2, err)
 	}
 
-	th, err := buildTargetHeader(f1, f2)
+	fi1, err := os.Stat(f1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fi2, err := os.Stat(f2)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -161,8 +170,10 @@ func ExampleDiff_PrintUniHunks_uniFileDiff() {
 	diff := gonp.New(a, b)
 	diff.Compose()
 
-	fmt.Printf(th.String())
-	diff.PrintUniHunks(diff.UnifiedHunks())
+	fileDiff := gonp.NewFileDiff(f1, f2, diff.UnifiedHunks())
+	fileDiff.OldTime = fi1.ModTime().Format(gonp.TimeFormat)
+	fileDiff.NewTime = fi2.ModTime().Format(gonp.TimeFormat)
+	gonp.PrintFileDiffs([]gonp.FileDiff{fileDiff})
 }
 
 func ExampleDiff_Patch_uniIntDiff() {
@@ -250,17 +261,6 @@ func equalsStringSlice(a, b []string) bool {
 	return true
 }
 
-// Target consists of a path and mtime of file.
-type Target struct {
-	fname string
-	mtime time.Time
-}
-
-// TargetHeader has 2 targets based on pathes and mtimes based on 2 files
-type TargetHeader struct {
-	targets []Target
-}
-
 // getLines returns a file contents as string array
 func getLines(f string) ([]string, error) {
 	fp, err := os.Open(f)
@@ -276,33 +276,3 @@ func getLines(f string) ([]string, error) {
 	}
 	return lines, nil
 }
-
-// builderTargetHeader returns TargetHeader constructed based on 2 files given as arguments
-func buildTargetHeader(f1, f2 string) (TargetHeader, error) {
-	fi1, err := os.Stat(f1)
-	if err != nil {
-		return TargetHeader{}, err
-	}
-	fi2, err := os.Stat(f2)
-	if err != nil {
-		return TargetHeader{}, err
-	}
-	return TargetHeader{
-		targets: []Target{
-			{fname: f1, mtime: fi1.ModTime()},
-			{fname: f2, mtime: fi2.ModTime()},
-		},
-	}, nil
-}
-
-// String returns a content of TargetHeader as a string
-func (th *TargetHeader) String() string {
-	if len(th.targets) != 2 {
-		return ""
-	}
-
-	var b bytes.Buffer
-	fmt.Fprintf(&b, "--- %s\t%s\n", th.targets[0].fname, th.targets[0].mtime.Format(time.RFC3339Nano))
-	fmt.Fprintf(&b, "+++ %s\t%s\n", th.targets[1].fname, th.targets[1].mtime.Format(time.RFC3339Nano))
-	return b.String()
-}
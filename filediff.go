@@ -0,0 +1,172 @@
+package gonp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// TimeFormat is the layout of timestamps GNU diff writes in file headers
+	TimeFormat = "2006-01-02 15:04:05.000000000 -0700"
+)
+
+// FileDiff is unified format difference of a single file
+type FileDiff struct {
+	// Git is extended header of difference produced by "git diff"
+	Git *GitHeader
+	// Header keeps other lines preceding "---", e.g. "diff -ru a/f b/f"
+	Header []string
+	// OldName and NewName are paths written after "---" and "+++"
+	OldName, NewName string
+	// OldTime and NewTime are timestamps following the paths, as written
+	OldTime, NewTime string
+	Hunks            []UniHunk[string]
+
+	// noNewline means the patch ends in this file without a newline, so that it is written back as is
+	noNewline bool
+}
+
+// NewFileDiff returns FileDiff between files named oldName and newName
+func NewFileDiff(oldName, newName string, uniHunks []UniHunk[string]) FileDiff {
+	return FileDiff{
+		OldName: oldName,
+		NewName: newName,
+		Hunks:   uniHunks,
+	}
+}
+
+// hasNames reports whether fileDiff has "---" and "+++" lines
+func (fileDiff *FileDiff) hasNames() bool {
+	return fileDiff.OldName != "" || fileDiff.NewName != ""
+}
+
+// ParseFileDiffs splits multi-file unified format difference into FileDiff.
+// A new file starts at each "diff " line or at a "---" line following hunks.
+func ParseFileDiffs(r io.Reader) ([]FileDiff, error) {
+	lr := newLineReader(r)
+	fileDiffs := make([]FileDiff, 0)
+	inBody := true // whether header of the current file is already complete
+
+	for {
+		text, ok := lr.next()
+		if !ok {
+			break
+		}
+
+		switch {
+		case strings.HasPrefix(text, "diff "):
+			if gh, ok := parseGitDiffLine(text); ok {
+				fileDiffs = append(fileDiffs, FileDiff{Git: gh})
+			} else {
+				fileDiffs = append(fileDiffs, FileDiff{Header: []string{text}})
+			}
+			inBody = false
+		case strings.HasPrefix(text, "--- ") && peekPrefix(lr, "+++ "):
+			if inBody {
+				fileDiffs = append(fileDiffs, FileDiff{})
+			}
+			fileDiff := &fileDiffs[len(fileDiffs)-1]
+			fileDiff.OldName, fileDiff.OldTime = splitFileName(text[len("--- "):])
+			text, _ = lr.next()
+			fileDiff.NewName, fileDiff.NewTime = splitFileName(text[len("+++ "):])
+			inBody = true
+		case strings.HasPrefix(text, "@@ "):
+			if len(fileDiffs) == 0 || !fileDiffs[len(fileDiffs)-1].hasNames() {
+				return nil, lr.errorf("hunk without file header")
+			}
+			uniHunk, err := parseUniHunk(lr, text)
+			if err != nil {
+				return nil, err
+			}
+			fileDiff := &fileDiffs[len(fileDiffs)-1]
+			fileDiff.Hunks = append(fileDiff.Hunks, uniHunk)
+		default:
+			if inBody {
+				fileDiffs = append(fileDiffs, FileDiff{})
+				inBody = false
+			}
+			fileDiff := &fileDiffs[len(fileDiffs)-1]
+			if fileDiff.Git != nil {
+				ok, err := fileDiff.Git.parseLine(text)
+				if err != nil {
+					return nil, lr.errorf("%v", err)
+				}
+				if ok {
+					continue
+				}
+			}
+			fileDiff.Header = append(fileDiff.Header, text)
+		}
+	}
+	if lr.lastErr != nil {
+		return nil, lr.lastErr
+	}
+	if len(fileDiffs) > 0 && !lr.nl {
+		fileDiffs[len(fileDiffs)-1].noNewline = true
+	}
+
+	return fileDiffs, nil
+}
+
+func peekPrefix(lr *lineReader, prefix string) bool {
+	text, ok := lr.peek()
+	return ok && strings.HasPrefix(text, prefix)
+}
+
+// splitFileName splits "name\ttimestamp" of file header
+func splitFileName(s string) (name, timestamp string) {
+	name, timestamp, _ = strings.Cut(s, "\t")
+	return name, timestamp
+}
+
+// PrintFileDiffs prints multi-file unified format difference
+func PrintFileDiffs(fileDiffs []FileDiff) {
+	fmt.Print(SprintFileDiffs(fileDiffs))
+}
+
+// SprintFileDiffs returns string about multi-file unified format difference
+func SprintFileDiffs(fileDiffs []FileDiff) string {
+	var buf bytes.Buffer
+	FprintFileDiffs(&buf, fileDiffs)
+	return buf.String()
+}
+
+// FprintFileDiffs emit about multi-file unified format difference to w
+func FprintFileDiffs(w io.Writer, fileDiffs []FileDiff) {
+	fprintFileDiffs(w, fileDiffs, nil)
+}
+
+func fprintFileDiffs(w io.Writer, fileDiffs []FileDiff, p *Palette) {
+	for _, fileDiff := range fileDiffs {
+		if fileDiff.noNewline {
+			// write the last line without its newline
+			var buf bytes.Buffer
+			fileDiff.noNewline = false
+			fprintFileDiffs(&buf, []FileDiff{fileDiff}, p)
+			w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+			continue
+		}
+		var header bytes.Buffer
+		if fileDiff.Git != nil {
+			fprintGitHeader(&header, fileDiff.Git)
+		}
+		for _, line := range fileDiff.Header {
+			fmt.Fprintf(&header, "%s\n", line)
+		}
+		if fileDiff.hasNames() {
+			fmt.Fprintf(&header, "--- %s\n", joinFileName(fileDiff.OldName, fileDiff.OldTime))
+			fmt.Fprintf(&header, "+++ %s\n", joinFileName(fileDiff.NewName, fileDiff.NewTime))
+		}
+		fprintFileHeader(w, header.String(), p)
+		fprintUniHunks(w, fileDiff.Hunks, p)
+	}
+}
+
+func joinFileName(name, timestamp string) string {
+	if timestamp == "" {
+		return name
+	}
+	return name + "\t" + timestamp
+}
@@ -0,0 +1,136 @@
+package gonp
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseFileDiffs(t *testing.T) {
+	text := `Only in a: removed.txt
+diff -ru a/foo.txt b/foo.txt
+--- a/foo.txt	2024-01-02 03:04:05.000000000 +0900
++++ b/foo.txt	2024-01-02 03:04:06.000000000 +0900
+@@ -1,3 +1,3 @@
+ a
+-b
++c
+ d
+@@ -10 +10 @@ section
+-x
+\ No newline at end of file
++y
+\ No newline at end of file
+diff --git a/bar.txt b/bar.txt
+index 1234567..89abcde 100644
+--- a/bar.txt
++++ b/bar.txt
+@@ -0,0 +1,2 @@
++1
++2
+diff --git a/bin.dat b/bin.dat
+Binary files a/bin.dat and b/bin.dat differ
+`
+	fileDiffs, err := ParseFileDiffs(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(fileDiffs) != 4 {
+		t.Fatalf("want: 4 files, got: %d (%v)", len(fileDiffs), fileDiffs)
+	}
+
+	tests := []struct {
+		header           []string
+		oldName, newName string
+		oldTime, newTime string
+		hunks            int
+	}{
+		{
+			header: []string{"Only in a: removed.txt"},
+		},
+		{
+			header:  []string{"diff -ru a/foo.txt b/foo.txt"},
+			oldName: "a/foo.txt",
+			newName: "b/foo.txt",
+			oldTime: "2024-01-02 03:04:05.000000000 +0900",
+			newTime: "2024-01-02 03:04:06.000000000 +0900",
+			hunks:   2,
+		},
+		{
+			oldName: "a/bar.txt",
+			newName: "b/bar.txt",
+			hunks:   1,
+		},
+		{
+			header: []string{"Binary files a/bin.dat and b/bin.dat differ"},
+		},
+	}
+
+	for i, tt := range tests {
+		fd := fileDiffs[i]
+		if !slices.Equal(tt.header, fd.Header) {
+			t.Fatalf("%d:header: want: %q, got: %q", i, tt.header, fd.Header)
+		}
+		if tt.oldName != fd.OldName || tt.newName != fd.NewName {
+			t.Fatalf("%d:names: want: %q %q, got: %q %q", i, tt.oldName, tt.newName, fd.OldName, fd.NewName)
+		}
+		if tt.oldTime != fd.OldTime || tt.newTime != fd.NewTime {
+			t.Fatalf("%d:times: want: %q %q, got: %q %q", i, tt.oldTime, tt.newTime, fd.OldTime, fd.NewTime)
+		}
+		if tt.hunks != len(fd.Hunks) {
+			t.Fatalf("%d:hunks: want: %d, got: %d", i, tt.hunks, len(fd.Hunks))
+		}
+	}
+
+	if actual := SprintFileDiffs(fileDiffs); actual != text {
+		t.Fatalf("round trip: want: %v, actual: %v", text, actual)
+	}
+}
+
+func TestParseFileDiffsWithoutNewline(t *testing.T) {
+	for _, text := range []string{
+		"--- a.txt\n+++ b.txt\n@@ -1 +1 @@\n-a\n+b",
+		"--- a.txt\n+++ b.txt\n@@ -1 +1 @@\n-a\n+b\n\\ No newline at end of file",
+		"diff -ru a/bin.dat b/bin.dat\nBinary files a/bin.dat and b/bin.dat differ",
+	} {
+		fileDiffs, err := ParseFileDiffs(strings.NewReader(text))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual := SprintFileDiffs(fileDiffs); actual != text {
+			t.Fatalf("round trip: want: %q, actual: %q", text, actual)
+		}
+	}
+}
+
+func TestParseFileDiffsError(t *testing.T) {
+	text := `@@ -1 +1 @@
+-a
++b
+`
+	_, err := ParseFileDiffs(strings.NewReader(text))
+	se, ok := err.(*SyntaxError)
+	if !ok || se.Line != 1 {
+		t.Fatalf("want: SyntaxError at line 1, got: %v", err)
+	}
+}
+
+func TestSprintFileDiffs(t *testing.T) {
+	a := []string{"a", "b", "c"}
+	b := []string{"a", "1", "c"}
+	diff := New(a, b)
+	diff.Compose()
+	actual := SprintFileDiffs([]FileDiff{NewFileDiff("a.txt", "b.txt", diff.UnifiedHunks())})
+	expected := `--- a.txt
++++ b.txt
+@@ -1,3 +1,3 @@
+ a
+-b
++1
+ c
+`
+	if actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+}
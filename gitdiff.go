@@ -0,0 +1,275 @@
+package gonp
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultGitFileMode is the mode of a regular file in git
+	DefaultGitFileMode = "100644"
+	// DevNull is the name of a missing file in "---" and "+++" lines
+	DevNull = "/dev/null"
+)
+
+// GitHeader is extended header of file difference produced by git
+type GitHeader struct {
+	// OldPath and NewPath are paths in "diff --git" line, with prefixes
+	OldPath, NewPath string
+	// OldMode and NewMode are set when mode of the file is changed
+	OldMode, NewMode string
+	NewFileMode      string
+	DeletedFileMode  string
+	// Similarity and Dissimilarity are percentages, 0 if missing
+	Similarity    int
+	Dissimilarity int
+	RenameFrom    string
+	RenameTo      string
+	CopyFrom      string
+	CopyTo        string
+	// IndexOld, IndexNew and IndexMode are taken from "index old..new mode"
+	IndexOld, IndexNew, IndexMode string
+
+	// hasSimilarity and hasDissimilarity report whether the lines were read, so that 0% is written back
+	hasSimilarity, hasDissimilarity bool
+}
+
+// IsNew reports whether the file is created
+func (gh *GitHeader) IsNew() bool { return gh.NewFileMode != "" }
+
+// IsDeleted reports whether the file is deleted
+func (gh *GitHeader) IsDeleted() bool { return gh.DeletedFileMode != "" }
+
+// IsRename reports whether the file is renamed
+func (gh *GitHeader) IsRename() bool { return gh.RenameFrom != "" || gh.RenameTo != "" }
+
+// IsCopy reports whether the file is copied
+func (gh *GitHeader) IsCopy() bool { return gh.CopyFrom != "" || gh.CopyTo != "" }
+
+// NewGitFileDiff returns FileDiff in the format git produces.
+// Empty oldPath means a new file and empty newPath means a deleted file,
+// different paths are recorded as a rename.
+func NewGitFileDiff(oldPath, newPath string, uniHunks []UniHunk[string]) FileDiff {
+	gh := &GitHeader{}
+	oldName, newName := "a/"+oldPath, "b/"+newPath
+	switch {
+	case oldPath == "":
+		gh.NewFileMode = DefaultGitFileMode
+		oldName = DevNull
+		gh.OldPath, gh.NewPath = "a/"+newPath, newName
+	case newPath == "":
+		gh.DeletedFileMode = DefaultGitFileMode
+		newName = DevNull
+		gh.OldPath, gh.NewPath = oldName, "b/"+oldPath
+	default:
+		gh.OldPath, gh.NewPath = oldName, newName
+		if oldPath != newPath {
+			gh.RenameFrom, gh.RenameTo = oldPath, newPath
+		}
+	}
+
+	fileDiff := FileDiff{Git: gh, Hunks: uniHunks}
+	if len(uniHunks) > 0 {
+		// git omits file names when there is no content change
+		fileDiff.OldName, fileDiff.NewName = oldName, newName
+	}
+
+	return fileDiff
+}
+
+// parseGitDiffLine parses "diff --git a/old b/new" line
+func parseGitDiffLine(text string) (*GitHeader, bool) {
+	s, ok := strings.CutPrefix(text, "diff --git ")
+	if !ok {
+		return nil, false
+	}
+
+	oldPath, newPath, ok := splitGitPaths(s)
+	if !ok {
+		return nil, false
+	}
+
+	return &GitHeader{OldPath: oldPath, NewPath: newPath}, true
+}
+
+// splitGitPaths splits "a/old b/new" into paths.
+// Paths may be quoted, otherwise the split where both paths have the same name is preferred.
+func splitGitPaths(s string) (oldPath, newPath string, ok bool) {
+	if strings.HasPrefix(s, `"`) {
+		n := quotedLen(s)
+		if n < 0 || n >= len(s) || s[n] != ' ' {
+			return "", "", false
+		}
+		return s[:n], s[n+1:], true
+	}
+	if strings.HasSuffix(s, `"`) {
+		i := strings.Index(s, ` "`)
+		if i < 0 {
+			return "", "", false
+		}
+		return s[:i], s[i+1:], true
+	}
+
+	first := -1
+	for i := 0; i < len(s); i++ {
+		if s[i] != ' ' {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if stripGitPrefix(s[:i]) == stripGitPrefix(s[i+1:]) {
+			return s[:i], s[i+1:], true
+		}
+	}
+	if first < 0 {
+		return "", "", false
+	}
+
+	return s[:first], s[first+1:], true
+}
+
+// quotedLen returns length of the C-style quoted string at the head of s or -1
+func quotedLen(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// stripGitPrefix removes the leading path component like "a/"
+func stripGitPrefix(path string) string {
+	if _, rest, ok := strings.Cut(path, "/"); ok {
+		return rest
+	}
+	return path
+}
+
+// GitPathName returns path without quotes and "a/" or "b/" prefix
+func GitPathName(path string) string {
+	if strings.HasPrefix(path, `"`) {
+		if s, err := strconv.Unquote(path); err == nil {
+			path = s
+		}
+	}
+	return stripGitPrefix(path)
+}
+
+// parseLine consumes a line of git extended header.
+// It reports false if the line is not an extended header.
+func (gh *GitHeader) parseLine(text string) (bool, error) {
+	key, value, ok := cutGitHeader(text)
+	if !ok {
+		return false, nil
+	}
+
+	switch key {
+	case "old mode":
+		gh.OldMode = value
+	case "new mode":
+		gh.NewMode = value
+	case "new file mode":
+		gh.NewFileMode = value
+	case "deleted file mode":
+		gh.DeletedFileMode = value
+	case "similarity index", "dissimilarity index":
+		n, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil || !strings.HasSuffix(value, "%") {
+			return false, fmt.Errorf("invalid %s %q", key, value)
+		}
+		if key == "similarity index" {
+			gh.Similarity, gh.hasSimilarity = n, true
+		} else {
+			gh.Dissimilarity, gh.hasDissimilarity = n, true
+		}
+	case "rename from":
+		gh.RenameFrom = value
+	case "rename to":
+		gh.RenameTo = value
+	case "copy from":
+		gh.CopyFrom = value
+	case "copy to":
+		gh.CopyTo = value
+	case "index":
+		hashes, mode, _ := strings.Cut(value, " ")
+		oldHash, newHash, ok := strings.Cut(hashes, "..")
+		if !ok {
+			return false, fmt.Errorf("invalid index %q", value)
+		}
+		gh.IndexOld, gh.IndexNew, gh.IndexMode = oldHash, newHash, mode
+	}
+
+	return true, nil
+}
+
+var gitHeaderKeys = []string{
+	"old mode",
+	"new mode",
+	"new file mode",
+	"deleted file mode",
+	"similarity index",
+	"dissimilarity index",
+	"rename from",
+	"rename to",
+	"copy from",
+	"copy to",
+	"index",
+}
+
+func cutGitHeader(text string) (key, value string, ok bool) {
+	for _, key := range gitHeaderKeys {
+		if value, ok := strings.CutPrefix(text, key+" "); ok {
+			return key, value, true
+		}
+	}
+	return "", "", false
+}
+
+// fprintGitHeader emits extended header in the order git writes it
+func fprintGitHeader(w io.Writer, gh *GitHeader) {
+	fmt.Fprintf(w, "diff --git %s %s\n", gh.OldPath, gh.NewPath)
+	if gh.OldMode != "" {
+		fmt.Fprintf(w, "old mode %s\n", gh.OldMode)
+	}
+	if gh.NewMode != "" {
+		fmt.Fprintf(w, "new mode %s\n", gh.NewMode)
+	}
+	if gh.DeletedFileMode != "" {
+		fmt.Fprintf(w, "deleted file mode %s\n", gh.DeletedFileMode)
+	}
+	if gh.NewFileMode != "" {
+		fmt.Fprintf(w, "new file mode %s\n", gh.NewFileMode)
+	}
+	if gh.Similarity > 0 || gh.hasSimilarity {
+		fmt.Fprintf(w, "similarity index %d%%\n", gh.Similarity)
+	}
+	if gh.Dissimilarity > 0 || gh.hasDissimilarity {
+		fmt.Fprintf(w, "dissimilarity index %d%%\n", gh.Dissimilarity)
+	}
+	if gh.RenameFrom != "" {
+		fmt.Fprintf(w, "rename from %s\n", gh.RenameFrom)
+	}
+	if gh.RenameTo != "" {
+		fmt.Fprintf(w, "rename to %s\n", gh.RenameTo)
+	}
+	if gh.CopyFrom != "" {
+		fmt.Fprintf(w, "copy from %s\n", gh.CopyFrom)
+	}
+	if gh.CopyTo != "" {
+		fmt.Fprintf(w, "copy to %s\n", gh.CopyTo)
+	}
+	if gh.IndexOld != "" || gh.IndexNew != "" {
+		if gh.IndexMode != "" {
+			fmt.Fprintf(w, "index %s..%s %s\n", gh.IndexOld, gh.IndexNew, gh.IndexMode)
+		} else {
+			fmt.Fprintf(w, "index %s..%s\n", gh.IndexOld, gh.IndexNew)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package gonp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGitFileDiffs(t *testing.T) {
+	text := `diff --git a/old.txt b/new.txt
+old mode 100644
+new mode 100755
+similarity index 90%
+rename from old.txt
+rename to new.txt
+index 1234567..89abcde
+--- a/old.txt
++++ b/new.txt
+@@ -1 +1 @@
+-a
++b
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+index 1234567..0000000
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-a
+diff --git a/empty.txt b/empty.txt
+new file mode 100644
+index 0000000..e69de29
+diff --git "a/with space.txt" "b/with space.txt"
+copy from with space.txt
+copy to with space.txt
+`
+	fileDiffs, err := ParseFileDiffs(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fileDiffs) != 4 {
+		t.Fatalf("want: 4 files, got: %d", len(fileDiffs))
+	}
+
+	tests := []GitHeader{
+		{
+			OldPath: "a/old.txt", NewPath: "b/new.txt",
+			OldMode: "100644", NewMode: "100755",
+			Similarity: 90, hasSimilarity: true,
+			RenameFrom: "old.txt", RenameTo: "new.txt",
+			IndexOld: "1234567", IndexNew: "89abcde",
+		},
+		{
+			OldPath: "a/gone.txt", NewPath: "b/gone.txt",
+			DeletedFileMode: "100644",
+			IndexOld:        "1234567", IndexNew: "0000000", IndexMode: "",
+		},
+		{
+			OldPath: "a/empty.txt", NewPath: "b/empty.txt",
+			NewFileMode: "100644",
+			IndexOld:    "0000000", IndexNew: "e69de29",
+		},
+		{
+			OldPath: `"a/with space.txt"`, NewPath: `"b/with space.txt"`,
+			CopyFrom: "with space.txt", CopyTo: "with space.txt",
+		},
+	}
+
+	for i, tt := range tests {
+		gh := fileDiffs[i].Git
+		if gh == nil {
+			t.Fatalf("%d: git header is missing", i)
+		}
+		if *gh != tt {
+			t.Fatalf("%d: want: %+v, got: %+v", i, tt, *gh)
+		}
+		if len(fileDiffs[i].Header) != 0 {
+			t.Fatalf("%d: unexpected header lines: %q", i, fileDiffs[i].Header)
+		}
+	}
+
+	if !fileDiffs[0].Git.IsRename() || !fileDiffs[1].Git.IsDeleted() || !fileDiffs[2].Git.IsNew() || !fileDiffs[3].Git.IsCopy() {
+		t.Fatalf("unexpected kinds of changes: %+v", fileDiffs)
+	}
+
+	if actual := SprintFileDiffs(fileDiffs); actual != text {
+		t.Fatalf("round trip: want: %v, actual: %v", text, actual)
+	}
+}
+
+func TestParseGitFileDiffsZeroSimilarity(t *testing.T) {
+	text := `diff --git a/a.txt b/b.txt
+similarity index 0%
+dissimilarity index 0%
+rename from a.txt
+rename to b.txt
+`
+	fileDiffs, err := ParseFileDiffs(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := SprintFileDiffs(fileDiffs); actual != text {
+		t.Fatalf("round trip: want: %v, actual: %v", text, actual)
+	}
+}
+
+func TestParseGitFileDiffsError(t *testing.T) {
+	text := `diff --git a/f b/f
+similarity index many
+`
+	_, err := ParseFileDiffs(strings.NewReader(text))
+	se, ok := err.(*SyntaxError)
+	if !ok || se.Line != 2 {
+		t.Fatalf("want: SyntaxError at line 2, got: %v", err)
+	}
+}
+
+func TestNewGitFileDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldPath  string
+		newPath  string
+		a        []string
+		b        []string
+		expected string
+	}{
+		{
+			name:    "modify",
+			oldPath: "f.txt",
+			newPath: "f.txt",
+			a:       []string{"a"},
+			b:       []string{"b"},
+			expected: `diff --git a/f.txt b/f.txt
+--- a/f.txt
++++ b/f.txt
+@@ -1,1 +1,1 @@
+-a
++b
+`,
+		},
+		{
+			name:    "create",
+			newPath: "f.txt",
+			b:       []string{"b"},
+			expected: `diff --git a/f.txt b/f.txt
+new file mode 100644
+--- /dev/null
++++ b/f.txt
+@@ -0,0 +1,1 @@
++b
+`,
+		},
+		{
+			name:    "delete",
+			oldPath: "f.txt",
+			a:       []string{"a"},
+			expected: `diff --git a/f.txt b/f.txt
+deleted file mode 100644
+--- a/f.txt
++++ /dev/null
+@@ -1,1 +0,0 @@
+-a
+`,
+		},
+		{
+			name:    "pure rename",
+			oldPath: "f.txt",
+			newPath: "g.txt",
+			a:       []string{"a"},
+			b:       []string{"a"},
+			expected: `diff --git a/f.txt b/g.txt
+rename from f.txt
+rename to g.txt
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		diff := New(tt.a, tt.b)
+		diff.Compose()
+		actual := SprintFileDiffs([]FileDiff{NewGitFileDiff(tt.oldPath, tt.newPath, diff.UnifiedHunks())})
+		if actual != tt.expected {
+			t.Fatalf(":%s: want: %v, actual: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestGitPathName(t *testing.T) {
+	tests := map[string]string{
+		"a/foo/bar.txt":         "foo/bar.txt",
+		`"b/with space.txt"`:    "with space.txt",
+		`"a/\303\251t\303\251"`: "été",
+	}
+	for path, expected := range tests {
+		if actual := GitPathName(path); actual != expected {
+			t.Fatalf("%s: want: %q, got: %q", path, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package gonp
+
+import (
+	"slices"
+)
+
+const (
+	// histogramMaxChain is the limit of occurrences of an element to be used for splitting
+	histogramMaxChain = 64
+	// histogramMinRegion is the size of regions composed with AlgorithmONP directly
+	histogramMinRegion = 4
+)
+
+// composeHistogram composes diff between a and b with AlgorithmHistogram.
+// A region is split at the longest common part containing the element occurring least in a,
+// and regions without such an element or small ones are composed with AlgorithmONP.
+func (diff *Diff[T]) composeHistogram() {
+	var as, bs []int
+
+	stack := []editBox{{x1: diff.aLen, y1: diff.bLen}}
+	for len(stack) > 0 {
+		box := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if box.common {
+			diff.appendDiagonal(box)
+			continue
+		}
+		box, stack = diff.trimBox(box, stack)
+
+		if box.x1-box.x0+box.y1-box.y0 < histogramMinRegion {
+			diff.composeBox(box)
+			continue
+		}
+		if as == nil {
+			as, bs = diff.elemClasses()
+		}
+		region, ok := histogramRegion(box, as, bs)
+		if !ok {
+			diff.composeBox(box)
+			continue
+		}
+		stack = append(stack,
+			editBox{x0: region.x1, x1: box.x1, y0: region.y1, y1: box.y1},
+			region,
+			editBox{x0: box.x0, x1: region.x0, y0: box.y0, y1: region.y0},
+		)
+	}
+}
+
+// histogramRegion returns the common region of box containing the element occurring least in a,
+// the longest one among them
+func histogramRegion(box editBox, as, bs []int) (editBox, bool) {
+	occurrences := make(map[int][]int)
+	for x := box.x0; x < box.x1; x++ {
+		occurrences[as[x]] = append(occurrences[as[x]], x)
+	}
+
+	best, bestCount := editBox{}, histogramMaxChain+1
+	for y := box.y0; y < box.y1; {
+		next := y + 1
+		xs := occurrences[bs[y]]
+		if len(xs) > histogramMaxChain {
+			y = next
+			continue
+		}
+
+		for _, x := range xs {
+			region := editBox{x0: x, x1: x + 1, y0: y, y1: y + 1, common: true}
+			for region.x0 > box.x0 && region.y0 > box.y0 && as[region.x0-1] == bs[region.y0-1] {
+				region.x0--
+				region.y0--
+			}
+			for region.x1 < box.x1 && region.y1 < box.y1 && as[region.x1] == bs[region.y1] {
+				region.x1++
+				region.y1++
+			}
+
+			count := histogramMaxChain + 1
+			for i := region.x0; i < region.x1; i++ {
+				count = min(count, len(occurrences[as[i]]))
+			}
+			if count < bestCount || (count == bestCount && region.x1-region.x0 > best.x1-best.x0) {
+				best, bestCount = region, count
+			}
+			next = max(next, region.y1)
+		}
+		y = next
+	}
+	return best, bestCount <= histogramMaxChain
+}
+
+// elemClasses returns classes of elements of a and b, which are equal for equal elements.
+// Classes are found with the key function set by SetKey, or by sorting with cmp.
+func (diff *Diff[T]) elemClasses() ([]int, []int) {
+	as, bs := make([]int, diff.aLen), make([]int, diff.bLen)
+
+	if diff.key != nil {
+		classes := make(map[any]int)
+		class := func(e T) int {
+			k := diff.key(e)
+			c, ok := classes[k]
+			if !ok {
+				c = len(classes)
+				classes[k] = c
+			}
+			return c
+		}
+		for i, e := range diff.a {
+			as[i] = class(e)
+		}
+		for i, e := range diff.b {
+			bs[i] = class(e)
+		}
+		return as, bs
+	}
+
+	// positions of b follow ones of a
+	positions := make([]int, diff.aLen+diff.bLen)
+	for i := range positions {
+		positions[i] = i
+	}
+	elem := func(i int) T {
+		if i < diff.aLen {
+			return diff.a[i]
+		}
+		return diff.b[i-diff.aLen]
+	}
+	slices.SortFunc(positions, func(i, j int) int { return diff.cmp(elem(i), elem(j)) })
+
+	class := 0
+	for k, i := range positions {
+		if k > 0 && diff.cmp(elem(positions[k-1]), elem(i)) != 0 {
+			class++
+		}
+		if i < diff.aLen {
+			as[i] = class
+		} else {
+			bs[i-diff.aLen] = class
+		}
+	}
+	return as, bs
+}
@@ -0,0 +1,93 @@
+package gonp
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestElemClasses(t *testing.T) {
+	a := []string{"x", "y", "x", "z"}
+	b := []string{"y", "w", "x", "y", "v"}
+
+	for _, diff := range []*Diff[string]{New(a, b), NewCmp(a, b, strings.Compare)} {
+		as, bs := diff.elemClasses()
+		classes := append(as, bs...)
+		elems := append(append([]string{}, diff.a...), diff.b...)
+		for i := range elems {
+			for j := range elems {
+				if (classes[i] == classes[j]) != (elems[i] == elems[j]) {
+					t.Fatalf(":%s,%s: want: %v, actual: %v", elems[i], elems[j], elems[i] == elems[j], classes[i] == classes[j])
+				}
+			}
+		}
+	}
+}
+
+func TestHistogramRegion(t *testing.T) {
+	// 2 occurs least in a and the region around it is extended
+	as := []int{0, 1, 0, 2, 0, 1, 0}
+	bs := []int{1, 0, 2, 0, 3}
+	expected := editBox{x0: 1, x1: 5, y0: 0, y1: 4, common: true}
+	region, ok := histogramRegion(editBox{x1: len(as), y1: len(bs)}, as, bs)
+	if !ok || region != expected {
+		t.Fatalf("want: %v, actual: %v", expected, region)
+	}
+
+	if _, ok := histogramRegion(editBox{x1: 2, y1: 2}, []int{0, 1}, []int{2, 3}); ok {
+		t.Fatal("no region is expected")
+	}
+}
+
+func TestDiffAlgorithmHistogram(t *testing.T) {
+	a := strings.Split("a b } c } d } e", " ")
+	b := strings.Split("a x } c } y } e z", " ")
+	diff := New(a, b).SetAlgorithm(AlgorithmHistogram)
+	diff.Compose()
+	checkSes(t, a, b, diff.Ses())
+	if diff.EditDistance() != 5 {
+		t.Fatalf("want: %v, actual: %v", 5, diff.EditDistance())
+	}
+}
+
+func TestDiffAlgorithmHistogramRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func() []int {
+		s := make([]int, rnd.Intn(80))
+		for i := range s {
+			s[i] = rnd.Intn(12)
+		}
+		return s
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		diff := New(a, b).SetAlgorithm(AlgorithmHistogram)
+		diff.Compose()
+		checkSes(t, a, b, diff.Ses())
+
+		sorted := NewCmp(a, b, cmp.Compare[int]).SetAlgorithm(AlgorithmHistogram)
+		sorted.Compose()
+		if !reflect.DeepEqual(sorted.Ses(), diff.Ses()) {
+			t.Fatalf(":%v,%v: want: %v, actual: %v", a, b, diff.Ses(), sorted.Ses())
+		}
+
+		onlyEd := New(a, b).SetAlgorithm(AlgorithmHistogram).OnlyEd()
+		onlyEd.Compose()
+		if onlyEd.EditDistance() != diff.EditDistance() {
+			t.Fatalf(":%v,%v: want: %v, actual: %v", a, b, diff.EditDistance(), onlyEd.EditDistance())
+		}
+	}
+}
+
+func BenchmarkStringDiffComposeHistogram(b *testing.B) {
+	s1 := []rune("abc")
+	s2 := []rune("abd")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		diff := New(s1, s2).SetAlgorithm(AlgorithmHistogram)
+		diff.Compose()
+	}
+}
@@ -0,0 +1,196 @@
+package gonp
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// HTMLOptions configures HTML format difference.
+//
+// Rendered table has class "diff" and its rows have class "common", "delete", "add" or "change".
+// Cells of line numbers have class "lineno" and ones of elements have class "delete", "add" or "common".
+// Changed characters in a pair of deleted and added lines are wrapped in <del> and <ins>.
+type HTMLOptions[T any] struct {
+	// Format formats an element, fmt.Sprint if nil
+	Format func(T) string
+	// NoHighlight disables highlighting of changed characters
+	NoHighlight bool
+}
+
+func (opts *HTMLOptions[T]) format(e T) string {
+	if opts.Format == nil {
+		return fmt.Sprint(e)
+	}
+	return opts.Format(e)
+}
+
+// htmlCells returns escaped contents of a row, highlighting changed characters of a pair
+func htmlCells[T any](row sideBySideRow[T], opts HTMLOptions[T]) (left, right string) {
+	if row.marker == '|' && !opts.NoHighlight {
+		return highlightPair(opts.format(row.left.elem), opts.format(row.right.elem))
+	}
+	if row.left != nil {
+		left = html.EscapeString(opts.format(row.left.elem))
+	}
+	if row.right != nil {
+		right = html.EscapeString(opts.format(row.right.elem))
+	}
+	return left, right
+}
+
+// highlightPair returns escaped old and new strings
+// with changed runes wrapped in <del> and <ins> respectively
+func highlightPair(older, newer string) (string, string) {
+	diff := New([]rune(older), []rune(newer))
+	diff.Compose()
+
+	var ob, nb strings.Builder
+	for _, run := range elemRuns(diff.ses) {
+		s := html.EscapeString(string(run.elems))
+		switch run.typ {
+		case SesCommon:
+			ob.WriteString(s)
+			nb.WriteString(s)
+		case SesDelete:
+			fmt.Fprintf(&ob, "<del>%s</del>", s)
+		case SesAdd:
+			fmt.Fprintf(&nb, "<ins>%s</ins>", s)
+		}
+	}
+	return ob.String(), nb.String()
+}
+
+// elemRun is a run of SES elements of the same type
+type elemRun[T any] struct {
+	typ   SesType
+	elems []T
+}
+
+// elemRuns splits SES into runs of elements of the same type
+func elemRuns[T any](ses []SesElem[T]) []elemRun[T] {
+	runs := make([]elemRun[T], 0)
+	for _, e := range ses {
+		if n := len(runs); n > 0 && runs[n-1].typ == e.typ {
+			runs[n-1].elems = append(runs[n-1].elems, e.elem)
+			continue
+		}
+		runs = append(runs, elemRun[T]{typ: e.typ, elems: []T{e.elem}})
+	}
+	return runs
+}
+
+func htmlRowClass(marker byte) string {
+	switch marker {
+	case '<':
+		return "delete"
+	case '>':
+		return "add"
+	case '|':
+		return "change"
+	default:
+		return "common"
+	}
+}
+
+func htmlLineNo(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
+
+// PrintHTMLSideBySide prints HTML table of side-by-side format difference between a and b
+func (diff *Diff[T]) PrintHTMLSideBySide(opts HTMLOptions[T]) {
+	fmt.Print(diff.SprintHTMLSideBySide(opts))
+}
+
+// SprintHTMLSideBySide returns HTML table of side-by-side format difference between a and b
+func (diff *Diff[T]) SprintHTMLSideBySide(opts HTMLOptions[T]) string {
+	var buf bytes.Buffer
+	diff.FprintHTMLSideBySide(&buf, opts)
+	return buf.String()
+}
+
+// FprintHTMLSideBySide emit HTML table of side-by-side format difference between a and b to w.
+// Each row has line number and element of a, then ones of b.
+func (diff *Diff[T]) FprintHTMLSideBySide(w io.Writer, opts HTMLOptions[T]) {
+	fmt.Fprint(w, "<table class=\"diff\">\n")
+	for _, row := range sideBySideRows(diff.ses) {
+		left, right := htmlCells(row, opts)
+		var aIdx, bIdx int
+		leftClass, rightClass := "common", "common"
+		if row.left != nil {
+			aIdx = row.left.aIdx
+			if row.marker != ' ' {
+				leftClass = "delete"
+			}
+		}
+		if row.right != nil {
+			bIdx = row.right.bIdx
+			if row.marker != ' ' {
+				rightClass = "add"
+			}
+		}
+		fmt.Fprintf(w, "<tr class=\"%s\">", htmlRowClass(row.marker))
+		fmt.Fprintf(w, "<td class=\"lineno\">%s</td><td class=\"%s\">%s</td>", htmlLineNo(aIdx), leftClass, left)
+		fmt.Fprintf(w, "<td class=\"lineno\">%s</td><td class=\"%s\">%s</td>", htmlLineNo(bIdx), rightClass, right)
+		fmt.Fprint(w, "</tr>\n")
+	}
+	fmt.Fprint(w, "</table>\n")
+}
+
+// PrintHTMLInline prints HTML table of inline format difference between a and b
+func (diff *Diff[T]) PrintHTMLInline(opts HTMLOptions[T]) {
+	fmt.Print(diff.SprintHTMLInline(opts))
+}
+
+// SprintHTMLInline returns HTML table of inline format difference between a and b
+func (diff *Diff[T]) SprintHTMLInline(opts HTMLOptions[T]) string {
+	var buf bytes.Buffer
+	diff.FprintHTMLInline(&buf, opts)
+	return buf.String()
+}
+
+// FprintHTMLInline emit HTML table of inline format difference between a and b to w.
+// Each row has line numbers of a and b and an element,
+// deleted elements of a run of changes are followed by added ones like unified format.
+func (diff *Diff[T]) FprintHTMLInline(w io.Writer, opts HTMLOptions[T]) {
+	row := func(class string, aIdx, bIdx int, content string) {
+		fmt.Fprintf(w, "<tr class=\"%s\"><td class=\"lineno\">%s</td><td class=\"lineno\">%s</td><td class=\"%s\">%s</td></tr>\n",
+			class, htmlLineNo(aIdx), htmlLineNo(bIdx), class, content)
+	}
+
+	fmt.Fprint(w, "<table class=\"diff\">\n")
+	rows := sideBySideRows(diff.ses)
+	for i := 0; i < len(rows); {
+		if rows[i].marker == ' ' {
+			left, _ := htmlCells(rows[i], opts)
+			row("common", rows[i].left.aIdx, rows[i].right.bIdx, left)
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(rows) && rows[j].marker != ' ' {
+			j++
+		}
+		rights := make([]string, j-i)
+		for k := i; k < j; k++ {
+			left, right := htmlCells(rows[k], opts)
+			rights[k-i] = right
+			if rows[k].left != nil {
+				row("delete", rows[k].left.aIdx, 0, left)
+			}
+		}
+		for k := i; k < j; k++ {
+			if rows[k].right != nil {
+				row("add", 0, rows[k].right.bIdx, rights[k-i])
+			}
+		}
+		i = j
+	}
+	fmt.Fprint(w, "</table>\n")
+}
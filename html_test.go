@@ -0,0 +1,58 @@
+package gonp
+
+import (
+	"testing"
+)
+
+func TestDiffSprintHTMLSideBySide(t *testing.T) {
+	a := []string{"a", "<b>", "c", "d"}
+	b := []string{"a", "<i>", "c", "e", "f"}
+	diff := New(a, b)
+	diff.Compose()
+
+	actual := diff.SprintHTMLSideBySide(HTMLOptions[string]{})
+	expected := `<table class="diff">
+<tr class="common"><td class="lineno">1</td><td class="common">a</td><td class="lineno">1</td><td class="common">a</td></tr>
+<tr class="change"><td class="lineno">2</td><td class="delete">&lt;<del>b</del>&gt;</td><td class="lineno">2</td><td class="add">&lt;<ins>i</ins>&gt;</td></tr>
+<tr class="common"><td class="lineno">3</td><td class="common">c</td><td class="lineno">3</td><td class="common">c</td></tr>
+<tr class="change"><td class="lineno">4</td><td class="delete"><del>d</del></td><td class="lineno">4</td><td class="add"><ins>e</ins></td></tr>
+<tr class="add"><td class="lineno"></td><td class="common"></td><td class="lineno">5</td><td class="add">f</td></tr>
+</table>
+`
+	if actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestDiffSprintHTMLInline(t *testing.T) {
+	a := []string{"a", "foo bar", "baz", "c"}
+	b := []string{"a", "foo & bar", "c"}
+	diff := New(a, b)
+	diff.Compose()
+
+	actual := diff.SprintHTMLInline(HTMLOptions[string]{})
+	expected := `<table class="diff">
+<tr class="common"><td class="lineno">1</td><td class="lineno">1</td><td class="common">a</td></tr>
+<tr class="delete"><td class="lineno">2</td><td class="lineno"></td><td class="delete">foo bar</td></tr>
+<tr class="delete"><td class="lineno">3</td><td class="lineno"></td><td class="delete">baz</td></tr>
+<tr class="add"><td class="lineno"></td><td class="lineno">2</td><td class="add">foo <ins>&amp; </ins>bar</td></tr>
+<tr class="common"><td class="lineno">4</td><td class="lineno">3</td><td class="common">c</td></tr>
+</table>
+`
+	if actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+
+	actual = diff.SprintHTMLInline(HTMLOptions[string]{NoHighlight: true})
+	expected = `<table class="diff">
+<tr class="common"><td class="lineno">1</td><td class="lineno">1</td><td class="common">a</td></tr>
+<tr class="delete"><td class="lineno">2</td><td class="lineno"></td><td class="delete">foo bar</td></tr>
+<tr class="delete"><td class="lineno">3</td><td class="lineno"></td><td class="delete">baz</td></tr>
+<tr class="add"><td class="lineno"></td><td class="lineno">2</td><td class="add">foo &amp; bar</td></tr>
+<tr class="common"><td class="lineno">4</td><td class="lineno">3</td><td class="common">c</td></tr>
+</table>
+`
+	if actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,97 @@
+package gonp
+
+// Invert returns SES element turning b into a
+func (e *SesElem[T]) Invert() SesElem[T] {
+	switch e.typ {
+	case SesDelete:
+		return SesElem[T]{elem: e.elem, typ: SesAdd, aIdx: 0, bIdx: e.aIdx}
+	case SesAdd:
+		return SesElem[T]{elem: e.elem, typ: SesDelete, aIdx: e.bIdx, bIdx: 0}
+	default:
+		return SesElem[T]{elem: e.elem, typ: e.typ, aIdx: e.bIdx, bIdx: e.aIdx}
+	}
+}
+
+// InvertSes returns SES turning b into a.
+// Deleted elements are placed before added ones as Compose does.
+func InvertSes[T any](ses []SesElem[T]) []SesElem[T] {
+	inverted, _ := invertChanges(ses)
+	return inverted
+}
+
+// Invert returns hunk turning b into a
+func (uniHunk *UniHunk[T]) Invert() UniHunk[T] {
+	changes, moved := invertChanges(uniHunk.changes)
+	inverted := UniHunk[T]{
+		a: uniHunk.c, b: uniHunk.d, c: uniHunk.a, d: uniHunk.b,
+		section: uniHunk.section,
+		changes: changes,
+	}
+	for i, note := range uniHunk.notes {
+		if inverted.notes == nil {
+			inverted.notes = make(map[int]string)
+		}
+		inverted.notes[moved[i]] = note
+	}
+
+	return inverted
+}
+
+// InvertUniHunks returns unified format difference turning b into a
+func InvertUniHunks[T any](uniHunks []UniHunk[T]) []UniHunk[T] {
+	inverted := make([]UniHunk[T], 0, len(uniHunks))
+	for _, h := range uniHunks {
+		inverted = append(inverted, h.Invert())
+	}
+	return inverted
+}
+
+// invertChanges inverts each element of changes, moving deleted elements
+// in front of added ones in every run of changes.
+// It also returns the new index of each element.
+func invertChanges[T any](changes []SesElem[T]) ([]SesElem[T], []int) {
+	inverted := make([]SesElem[T], 0, len(changes))
+	moved := make([]int, len(changes))
+	for i := 0; i < len(changes); {
+		if changes[i].typ == SesCommon {
+			moved[i] = len(inverted)
+			inverted = append(inverted, changes[i].Invert())
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(changes) && changes[j].typ != SesCommon {
+			j++
+		}
+		for _, typ := range []SesType{SesAdd, SesDelete} {
+			// they become deleted and added elements after inversion
+			for k := i; k < j; k++ {
+				if changes[k].typ == typ {
+					moved[k] = len(inverted)
+					inverted = append(inverted, changes[k].Invert())
+				}
+			}
+		}
+		i = j
+	}
+
+	return inverted, moved
+}
+
+// ReversePatch applies SES between a and b to seq backwards, turning b into a
+func (diff *Diff[T]) ReversePatch(seq []T) ([]T, error) {
+	if diff.err != nil {
+		return []T{}, diff.err
+	}
+	if diff.ed == 0 {
+		return seq, nil
+	}
+	return patchSes(seq, InvertSes(diff.ses), diff.cmp, false)
+}
+
+// ReverseUniPatch applies unified format difference between a and b to seq backwards,
+// turning b into a
+func (diff *Diff[T]) ReverseUniPatch(seq []T, uniHunks []UniHunk[T]) ([]T, error) {
+	return diff.UniPatch(seq, InvertUniHunks(uniHunks))
+}
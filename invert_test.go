@@ -0,0 +1,116 @@
+package gonp
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInvertSes(t *testing.T) {
+	diff := New([]rune("abcdef"), []rune("dacfea"))
+	diff.Compose()
+
+	ses := InvertSes(diff.Ses())
+	expected := []SesElem[rune]{
+		{elem: 'd', typ: SesDelete, aIdx: 1, bIdx: 0},
+		{elem: 'a', typ: SesCommon, aIdx: 2, bIdx: 1},
+		{elem: 'b', typ: SesAdd, aIdx: 0, bIdx: 2},
+		{elem: 'c', typ: SesCommon, aIdx: 3, bIdx: 3},
+		{elem: 'd', typ: SesAdd, aIdx: 0, bIdx: 4},
+		{elem: 'e', typ: SesAdd, aIdx: 0, bIdx: 5},
+		{elem: 'f', typ: SesCommon, aIdx: 4, bIdx: 6},
+		{elem: 'e', typ: SesDelete, aIdx: 5, bIdx: 0},
+		{elem: 'a', typ: SesDelete, aIdx: 6, bIdx: 0},
+	}
+	if !equalsSesElemSlice(expected, ses, func(se1, se2 SesElem[rune]) int { return se1.Cmp(se2, cmp.Compare) }) {
+		t.Fatalf("want: %v, got: %v", expected, ses)
+	}
+
+	if !equalsSesElemSlice(diff.Ses(), InvertSes(ses), func(se1, se2 SesElem[rune]) int { return se1.Cmp(se2, cmp.Compare) }) {
+		t.Fatalf("double inversion: want: %v, got: %v", diff.Ses(), InvertSes(ses))
+	}
+}
+
+func TestInvertUniHunks(t *testing.T) {
+	a := []string{"a", "b", "c"}
+	b := []string{"a", "x", "y", "c"}
+	diff := New(a, b)
+	diff.Compose()
+
+	actual := SprintUniHunks(InvertUniHunks(diff.UnifiedHunks()))
+	expected := `@@ -1,4 +1,3 @@
+ a
+-x
+-y
++b
+ c
+`
+	if actual != expected {
+		t.Fatalf("want: %v, got: %v", expected, actual)
+	}
+}
+
+func TestReversePatch(t *testing.T) {
+	tests := []struct {
+		a string
+		b string
+	}{
+		{a: "", b: "def"},
+		{a: "abc", b: ""},
+		{a: "abc", b: "abd"},
+		{a: "abcdef", b: "dacfea"},
+		{a: "acbdeacbed", b: "acebdabbabed"},
+		{a: "abcaaaaaaaaaaaaaabd", b: "abdaaaaaaaaaaaaaabc"},
+	}
+
+	for _, tt := range tests {
+		diff := New([]rune(tt.a), []rune(tt.b))
+		diff.Compose()
+
+		patched, err := diff.ReversePatch([]rune(tt.b))
+		if err != nil || string(patched) != tt.a {
+			t.Fatalf("reverse SES between '%s' and '%s': want: %s, got: %s (%v)", tt.a, tt.b, tt.a, string(patched), err)
+		}
+
+		patched, err = diff.ReverseUniPatch([]rune(tt.b), diff.UnifiedHunks())
+		if err != nil || string(patched) != tt.a {
+			t.Fatalf("reverse hunks between '%s' and '%s': want: %s, got: %s (%v)", tt.a, tt.b, tt.a, string(patched), err)
+		}
+
+		patched, _, err = diff.UniPatchFuzzy([]rune(tt.b), diff.UnifiedHunks(), PatchOptions{Reverse: true})
+		if err != nil || string(patched) != tt.a {
+			t.Fatalf("fuzzy reverse hunks between '%s' and '%s': want: %s, got: %s (%v)", tt.a, tt.b, tt.a, string(patched), err)
+		}
+	}
+}
+
+func TestAlreadyApplied(t *testing.T) {
+	a := strings.Split("abcdefghijklmnopqrst", "")
+	b := strings.Split("abcdeFghijklmnopQrst", "")
+	diff := New(a, b)
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	_, _, err := diff.UniPatchFuzzy(b, uniHunks, PatchOptions{})
+	var aae *AlreadyAppliedError
+	if !errors.As(err, &aae) || aae.Hunk != 1 {
+		t.Fatalf("want: AlreadyAppliedError for hunk #1, got: %v", err)
+	}
+
+	// the first hunk is applied, the second is not
+	seq := strings.Split("abcdeFghijklmnopqrst", "")
+	patched, results, rejected := diff.UniPatchPartial(seq, uniHunks, PatchOptions{})
+	if !slices.Equal(b, patched) {
+		t.Fatalf("want: %v, got: %v", b, patched)
+	}
+	if !errors.As(results[0].Err, &aae) || results[1].Err != nil || len(rejected) != 1 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	_, _, err = diff.UniPatchFuzzy(a, uniHunks, PatchOptions{Reverse: true})
+	if !errors.As(err, &aae) {
+		t.Fatalf("want: AlreadyAppliedError for reversed patch, got: %v", err)
+	}
+}
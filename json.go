@@ -0,0 +1,148 @@
+package gonp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var sesTypeNames = map[SesType]string{
+	SesDelete: "delete",
+	SesCommon: "common",
+	SesAdd:    "add",
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// SesType is written as "delete", "common" or "add", which is a stable part of the JSON schema.
+func (t SesType) MarshalText() ([]byte, error) {
+	name, ok := sesTypeNames[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown SES type %d", int(t))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *SesType) UnmarshalText(text []byte) error {
+	for typ, name := range sesTypeNames {
+		if name == string(text) {
+			*t = typ
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown SES type %q", text)
+}
+
+type sesElemJSON[T any] struct {
+	Type SesType `json:"type"`
+	Elem T       `json:"elem"`
+	AIdx int     `json:"aIdx,omitempty"`
+	BIdx int     `json:"bIdx,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// SesElem is written as an object of the stable schema:
+//
+//	{
+//	  "type": "add",  // SesType
+//	  "elem": "foo",  // element, encoded as T is
+//	  "aIdx": 0,      // 1-origin index of elem in a, omitted if elem is not in a
+//	  "bIdx": 3       // 1-origin index of elem in b, omitted if elem is not in b
+//	}
+func (e SesElem[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sesElemJSON[T]{Type: e.typ, Elem: e.elem, AIdx: e.aIdx, BIdx: e.bIdx})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It fails if an added element has aIdx or a deleted element has bIdx.
+func (e *SesElem[T]) UnmarshalJSON(data []byte) error {
+	var v sesElemJSON[T]
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	elem := SesElem[T]{elem: v.Elem, typ: v.Type, aIdx: v.AIdx, bIdx: v.BIdx}
+	if err := elem.checkIdx(); err != nil {
+		return err
+	}
+	*e = elem
+	return nil
+}
+
+type uniHunkJSON[T any] struct {
+	A       int            `json:"a"`
+	B       int            `json:"b"`
+	C       int            `json:"c"`
+	D       int            `json:"d"`
+	Section string         `json:"section,omitempty"`
+	Changes []SesElem[T]   `json:"changes"`
+	Header  string         `json:"header,omitempty"`
+	Notes   map[int]string `json:"notes,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// UniHunk is written as an object of the stable schema:
+//
+//	{
+//	  "a": 1, "b": 3, "c": 1, "d": 3, // @@ -a,b +c,d @@
+//	  "section": "func f()",          // text following the range, omitted if empty
+//	  "changes": [...],               // array of SesElem
+//	  "header": "@@ -1,3 +1,3 @@",    // range line of a parsed hunk, omitted if empty
+//	  "notes": {"2": "\\ No newline at end of file"} // lines following changes[i], omitted if empty
+//	}
+func (uniHunk UniHunk[T]) MarshalJSON() ([]byte, error) {
+	changes := uniHunk.changes
+	if changes == nil {
+		changes = []SesElem[T]{}
+	}
+	return json.Marshal(uniHunkJSON[T]{
+		A:       uniHunk.a,
+		B:       uniHunk.b,
+		C:       uniHunk.c,
+		D:       uniHunk.d,
+		Section: uniHunk.section,
+		Changes: changes,
+		Header:  uniHunk.header,
+		Notes:   uniHunk.notes,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It fails if the numbers of elements in the range do not match changes,
+// or header does not agree with the range and section.
+func (uniHunk *UniHunk[T]) UnmarshalJSON(data []byte) error {
+	var v uniHunkJSON[T]
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	for i := range v.Notes {
+		if i < 0 || i >= len(v.Changes) {
+			return fmt.Errorf("note follows change #%d out of %d changes", i, len(v.Changes))
+		}
+	}
+
+	if v.Header != "" {
+		rng, section, err := parseUniHunkHeader(v.Header)
+		if err != nil {
+			return err
+		}
+		if rng != [4]int{v.A, v.B, v.C, v.D} || section != v.Section {
+			return fmt.Errorf("header %q does not agree with range @@ -%d,%d +%d,%d @@ %s", v.Header, v.A, v.B, v.C, v.D, v.Section)
+		}
+	}
+
+	h := UniHunk[T]{
+		a:       v.A,
+		b:       v.B,
+		c:       v.C,
+		d:       v.D,
+		section: v.Section,
+		changes: v.Changes,
+		header:  v.Header,
+		notes:   v.Notes,
+	}
+	if err := h.checkRange(); err != nil {
+		return err
+	}
+	*uniHunk = h
+	return nil
+}
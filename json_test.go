@@ -0,0 +1,140 @@
+package gonp
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSesTypeText(t *testing.T) {
+	for _, typ := range []SesType{SesDelete, SesCommon, SesAdd} {
+		text, err := typ.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual SesType
+		if err := actual.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if actual != typ {
+			t.Fatalf(":%s: want: %v, actual: %v", text, typ, actual)
+		}
+	}
+
+	var typ SesType
+	if err := typ.UnmarshalText([]byte("replace")); err == nil {
+		t.Fatal("error is expected")
+	}
+	if _, err := SesType(3).MarshalText(); err == nil {
+		t.Fatal("error is expected")
+	}
+}
+
+func TestSesElemJSON(t *testing.T) {
+	diff := New([]string{"a", "b"}, []string{"a", "c"})
+	diff.Compose()
+
+	data, err := json.Marshal(diff.Ses())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"type":"common","elem":"a","aIdx":1,"bIdx":1},{"type":"delete","elem":"b","aIdx":2},{"type":"add","elem":"c","bIdx":2}]`
+	if string(data) != expected {
+		t.Fatalf("want: %v, actual: %v", expected, string(data))
+	}
+
+	var ses []SesElem[string]
+	if err := json.Unmarshal(data, &ses); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ses, diff.Ses()) {
+		t.Fatalf("want: %v, actual: %v", diff.Ses(), ses)
+	}
+
+	for _, data := range []string{
+		`{"type":"add","elem":"c","aIdx":3,"bIdx":2}`,
+		`{"type":"delete","elem":"b","aIdx":2,"bIdx":1}`,
+		`{"type":"common","elem":"a","aIdx":-1}`,
+	} {
+		var e SesElem[string]
+		if err := json.Unmarshal([]byte(data), &e); err == nil {
+			t.Fatalf(":%s: error is expected", data)
+		}
+	}
+}
+
+func TestUniHunkJSON(t *testing.T) {
+	diff := New([]int{1, 2, 3, 4}, []int{1, 3, 4, 5})
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	data, err := json.Marshal(uniHunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual []UniHunk[int]
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, uniHunks) {
+		t.Fatalf("want: %v, actual: %v", uniHunks, actual)
+	}
+
+	patched, err := diff.UniPatch([]int{1, 2, 3, 4}, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(patched, []int{1, 3, 4, 5}) {
+		t.Fatalf("want: %v, actual: %v", []int{1, 3, 4, 5}, patched)
+	}
+}
+
+func TestUniHunkJSONParsed(t *testing.T) {
+	text := `@@ -1,2 +1,2 @@ section
+ a
+-b
+\ No newline at end of file
++c
+\ No newline at end of file
+`
+	uniHunks, err := ParseUniHunks(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(uniHunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual []UniHunk[string]
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if s := SprintUniHunks(actual); s != text {
+		t.Fatalf("want: %q, actual: %q", text, s)
+	}
+}
+
+func TestUniHunkJSONError(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "count", data: `{"a":1,"b":2,"c":1,"d":1,"changes":[{"type":"common","elem":"a"}]}`},
+		{name: "type", data: `{"a":1,"b":1,"c":1,"d":1,"changes":[{"type":"replace","elem":"a"}]}`},
+		{name: "index", data: `{"a":1,"b":1,"c":1,"d":1,"changes":[{"type":"common","elem":"a","aIdx":-1}]}`},
+		{name: "add in a", data: `{"a":1,"b":0,"c":1,"d":1,"changes":[{"type":"add","elem":"a","aIdx":3,"bIdx":1}]}`},
+		{name: "delete in b", data: `{"a":1,"b":1,"c":1,"d":0,"changes":[{"type":"delete","elem":"a","aIdx":1,"bIdx":2}]}`},
+		{name: "header", data: `{"a":5,"b":1,"c":5,"d":1,"changes":[{"type":"common","elem":"a"}],"header":"@@ -1,3 +1,3 @@"}`},
+		{name: "header section", data: `{"a":1,"b":1,"c":1,"d":1,"section":"f","changes":[{"type":"common","elem":"a"}],"header":"@@ -1 +1 @@ g"}`},
+		{name: "invalid header", data: `{"a":1,"b":1,"c":1,"d":1,"changes":[{"type":"common","elem":"a"}],"header":"@@"}`},
+		{name: "note", data: `{"a":1,"b":1,"c":1,"d":1,"changes":[{"type":"common","elem":"a"}],"notes":{"1":"\\"}}`},
+	}
+
+	for _, tt := range tests {
+		var uniHunk UniHunk[string]
+		if err := json.Unmarshal([]byte(tt.data), &uniHunk); err == nil {
+			t.Fatalf(":%s: error is expected", tt.name)
+		}
+	}
+}
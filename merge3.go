@@ -0,0 +1,116 @@
+package gonp
+
+// Conflict is a region of three-way merge changed differently in ours and theirs
+type Conflict[T any] struct {
+	// Pos is 0-origin index in the merged sequence where the conflict is placed
+	Pos int
+	// BaseIdx, OursIdx and TheirsIdx are 1-origin positions of the region in each sequence
+	BaseIdx, OursIdx, TheirsIdx int
+	// Base, Ours and Theirs are the elements of the region in each sequence
+	Base, Ours, Theirs []T
+}
+
+// Merge3 merges changes between base and ours and between base and theirs like diff3.
+// Conflicting regions are left out of the merged sequence and returned separately.
+func Merge3[T any](base, ours, theirs []T, cmp func(T, T) int) ([]T, []Conflict[T]) {
+	mo := matchCommon(base, ours, cmp)
+	mt := matchCommon(base, theirs, cmp)
+
+	merged := make([]T, 0, max(len(ours), len(theirs)))
+	conflicts := make([]Conflict[T], 0)
+	i, o, t := 0, 0, 0
+	for i < len(base) || o < len(ours) || t < len(theirs) {
+		if i < len(base) && mo[i] == o && mt[i] == t {
+			// stable element
+			merged = append(merged, ours[o])
+			i++
+			o++
+			t++
+			continue
+		}
+
+		// find the next element common to all sequences
+		j := i
+		for j < len(base) && (mo[j] < 0 || mt[j] < 0) {
+			j++
+		}
+		oe, te := len(ours), len(theirs)
+		if j < len(base) {
+			oe, te = mo[j], mt[j]
+		}
+
+		b, x, y := base[i:j], ours[o:oe], theirs[t:te]
+		switch {
+		case equalsSlice(b, x, cmp):
+			merged = append(merged, y...)
+		case equalsSlice(b, y, cmp), equalsSlice(x, y, cmp):
+			merged = append(merged, x...)
+		default:
+			conflicts = append(conflicts, Conflict[T]{
+				Pos:     len(merged),
+				BaseIdx: i + 1, OursIdx: o + 1, TheirsIdx: t + 1,
+				Base: b, Ours: x, Theirs: y,
+			})
+		}
+		i, o, t = j, oe, te
+	}
+
+	return merged, conflicts
+}
+
+// matchCommon returns 0-origin index in b of each common element of a, or -1
+func matchCommon[T any](a, b []T, cmp func(T, T) int) []int {
+	diff := NewCmp(a, b, cmp)
+	diff.Compose()
+
+	m := make([]int, len(a))
+	for i := range m {
+		m[i] = -1
+	}
+	for _, e := range diff.ses {
+		if e.typ == SesCommon {
+			m[e.aIdx-1] = e.bIdx - 1
+		}
+	}
+
+	return m
+}
+
+func equalsSlice[T any](a, b []T, cmp func(T, T) int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if cmp(a[i], b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ConflictMarkers returns merged lines with conflicts rendered between
+// "<<<<<<<", "|||||||", "=======" and ">>>>>>>" markers labeled by names of sequences
+func ConflictMarkers(merged []string, conflicts []Conflict[string], ours, base, theirs string) []string {
+	lines := make([]string, 0, len(merged))
+	p := 0
+	for _, c := range conflicts {
+		lines = append(lines, merged[p:c.Pos]...)
+		lines = append(lines, conflictMarker("<<<<<<<", ours))
+		lines = append(lines, c.Ours...)
+		lines = append(lines, conflictMarker("|||||||", base))
+		lines = append(lines, c.Base...)
+		lines = append(lines, "=======")
+		lines = append(lines, c.Theirs...)
+		lines = append(lines, conflictMarker(">>>>>>>", theirs))
+		p = c.Pos
+	}
+
+	return append(lines, merged[p:]...)
+}
+
+func conflictMarker(marker, label string) string {
+	if label == "" {
+		return marker
+	}
+	return marker + " " + label
+}
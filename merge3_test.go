@@ -0,0 +1,107 @@
+package gonp
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		ours      string
+		theirs    string
+		merged    string
+		conflicts []Conflict[string]
+	}{
+		{
+			name:   "no changes",
+			base:   "abc",
+			ours:   "abc",
+			theirs: "abc",
+			merged: "abc",
+		},
+		{
+			name:   "changes in different places",
+			base:   "abcdefg",
+			ours:   "aXcdefg",
+			theirs: "abcdeYg",
+			merged: "aXcdeYg",
+		},
+		{
+			name:   "same change",
+			base:   "abc",
+			ours:   "aXc",
+			theirs: "aXc",
+			merged: "aXc",
+		},
+		{
+			name:   "insertion and deletion",
+			base:   "abcdef",
+			ours:   "Xabcdef",
+			theirs: "abcf",
+			merged: "Xabcf",
+		},
+		{
+			name:   "conflict",
+			base:   "abcde",
+			ours:   "abXde",
+			theirs: "abYde",
+			merged: "abde",
+			conflicts: []Conflict[string]{
+				{Pos: 2, BaseIdx: 3, OursIdx: 3, TheirsIdx: 3, Base: []string{"c"}, Ours: []string{"X"}, Theirs: []string{"Y"}},
+			},
+		},
+		{
+			name:   "conflict at end",
+			base:   "ab",
+			ours:   "abX",
+			theirs: "abY",
+			merged: "ab",
+			conflicts: []Conflict[string]{
+				{Pos: 2, BaseIdx: 3, OursIdx: 3, TheirsIdx: 3, Base: []string{}, Ours: []string{"X"}, Theirs: []string{"Y"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		merged, conflicts := Merge3(split(tt.base), split(tt.ours), split(tt.theirs), cmp.Compare[string])
+		if actual := strings.Join(merged, ""); actual != tt.merged {
+			t.Fatalf(":%s:merged: want: %s, got: %s", tt.name, tt.merged, actual)
+		}
+		if !slices.EqualFunc(tt.conflicts, conflicts, func(c1, c2 Conflict[string]) bool {
+			return c1.Pos == c2.Pos &&
+				c1.BaseIdx == c2.BaseIdx && c1.OursIdx == c2.OursIdx && c1.TheirsIdx == c2.TheirsIdx &&
+				slices.Equal(c1.Base, c2.Base) && slices.Equal(c1.Ours, c2.Ours) && slices.Equal(c1.Theirs, c2.Theirs)
+		}) {
+			t.Fatalf(":%s:conflicts: want: %+v, got: %+v", tt.name, tt.conflicts, conflicts)
+		}
+	}
+}
+
+func TestConflictMarkers(t *testing.T) {
+	base := []string{"a", "b", "c"}
+	ours := []string{"a", "x", "c"}
+	theirs := []string{"a", "y", "c"}
+	merged, conflicts := Merge3(base, ours, theirs, cmp.Compare[string])
+
+	actual := strings.Join(ConflictMarkers(merged, conflicts, "ours", "base", "theirs"), "\n")
+	expected := `a
+<<<<<<< ours
+x
+||||||| base
+b
+=======
+y
+>>>>>>> theirs
+c`
+	if actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+}
+
+func split(s string) []string {
+	return strings.Split(s, "")
+}
@@ -0,0 +1,217 @@
+package gonp
+
+// editBox is a rectangle a[x0:x1], b[y0:y1] of edit graph
+// composed by algorithms dividing edit graph
+type editBox struct {
+	x0, x1, y0, y1 int
+	// common means the box is a diagonal of common elements already found
+	common bool
+}
+
+// composeLinear composes diff between a and b with AlgorithmLinear.
+// Boxes are kept in a stack instead of recursion so that huge inputs do not exhaust the call stack.
+func (diff *Diff[T]) composeLinear() {
+	n := diff.aLen + diff.bLen + 5
+	vf, vb := make([]int, n), make([]int, n)
+
+	stack := []editBox{{x1: diff.aLen, y1: diff.bLen}}
+	for len(stack) > 0 {
+		box := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if box.common {
+			diff.appendDiagonal(box)
+			continue
+		}
+		box, stack = diff.trimBox(box, stack)
+
+		x, y, ok := diff.middleSnake(box, vf, vb)
+		if box.x0 == box.x1 || box.y0 == box.y1 || !ok {
+			diff.appendChanges(box)
+			continue
+		}
+		stack = append(stack,
+			editBox{x0: x, x1: box.x1, y0: y, y1: box.y1},
+			editBox{x0: box.x0, x1: x, y0: box.y0, y1: y},
+		)
+	}
+}
+
+// trimBox records common prefix of box now and pushes common suffix to stack,
+// returning the rest of box
+func (diff *Diff[T]) trimBox(box editBox, stack []editBox) (editBox, []editBox) {
+	for box.x0 < box.x1 && box.y0 < box.y1 && diff.cmp(diff.a[box.x0], diff.b[box.y0]) == 0 {
+		diff.appendCommon(box.x0, box.y0)
+		box.x0++
+		box.y0++
+	}
+	x1, y1 := box.x1, box.y1
+	for box.x0 < box.x1 && box.y0 < box.y1 && diff.cmp(diff.a[box.x1-1], diff.b[box.y1-1]) == 0 {
+		box.x1--
+		box.y1--
+	}
+	if box.x1 < x1 {
+		stack = append(stack, editBox{x0: box.x1, x1: x1, y0: box.y1, y1: y1, common: true})
+	}
+	return box, stack
+}
+
+// appendDiagonal records elements on the diagonal of box as common elements
+func (diff *Diff[T]) appendDiagonal(box editBox) {
+	for x, y := box.x0, box.y0; x < box.x1; x, y = x+1, y+1 {
+		diff.appendCommon(x, y)
+	}
+}
+
+// middleSnake finds a point on a shortest path through box,
+// extending paths from both corners of box until they overlap
+func (diff *Diff[T]) middleSnake(box editBox, vf, vb []int) (int, int, bool) {
+	n, m := box.x1-box.x0, box.y1-box.y0
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	for i := 0; i < 2*offset+1 && i < len(vf); i++ {
+		vf[i], vb[i] = -1, -1
+	}
+	vf[offset+1], vb[offset+1] = 0, 0
+
+	delta := n - m
+	front := delta%2 != 0
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+	for d := 0; d <= maxD; d++ {
+		// forward paths from (x0, y0)
+		for k := -d + fStart; k <= d-fEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && diff.cmp(diff.a[box.x0+x], diff.b[box.y0+y]) == 0 {
+				x++
+				y++
+			}
+			vf[offset+k] = x
+
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case front:
+				if kb := offset + delta - k; kb >= 0 && kb < 2*offset+1 && vb[kb] != -1 && x >= n-vb[kb] {
+					return box.x0 + x, box.y0 + y, true
+				}
+			}
+		}
+
+		// backward paths from (x1, y1)
+		for k := -d + bStart; k <= d-bEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && diff.cmp(diff.a[box.x1-x-1], diff.b[box.y1-y-1]) == 0 {
+				x++
+				y++
+			}
+			vb[offset+k] = x
+
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !front:
+				if kf := offset + delta - k; kf >= 0 && kf < 2*offset+1 && vf[kf] != -1 && vf[kf] >= n-x {
+					return box.x0 + vf[kf], box.y0 + vf[kf] - (kf - offset), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// appendChanges records all elements of box as deleted from a and added to b,
+// deleted ones first in the direction given to New
+func (diff *Diff[T]) appendChanges(box editBox) {
+	deletes := func() {
+		for x := box.x0; x < box.x1; x++ {
+			diff.appendDelete(x)
+		}
+	}
+	adds := func() {
+		for y := box.y0; y < box.y1; y++ {
+			diff.appendAdd(y)
+		}
+	}
+	if diff.reverse {
+		adds()
+		deletes()
+	} else {
+		deletes()
+		adds()
+	}
+}
+
+// appendEdit records an edit of typ taking a[x] and/or b[y] given to New
+func (diff *Diff[T]) appendEdit(typ SesType, x, y int) {
+	if diff.reverse {
+		x, y = y, x
+	}
+	switch {
+	case typ == SesCommon:
+		diff.appendCommon(x, y)
+	case (typ == SesDelete) != diff.reverse:
+		diff.appendDelete(x)
+	default:
+		diff.appendAdd(y)
+	}
+}
+
+// appendCommon records a[x] and b[y] as a common element
+func (diff *Diff[T]) appendCommon(x, y int) {
+	if diff.onlyEd {
+		return
+	}
+	if diff.reverse {
+		diff.lcs = append(diff.lcs, diff.b[y])
+		diff.ses = append(diff.ses, SesElem[T]{elem: diff.b[y], typ: SesCommon, aIdx: y + 1 + diff.oy, bIdx: x + 1 + diff.ox})
+	} else {
+		diff.lcs = append(diff.lcs, diff.a[x])
+		diff.ses = append(diff.ses, SesElem[T]{elem: diff.a[x], typ: SesCommon, aIdx: x + 1 + diff.ox, bIdx: y + 1 + diff.oy})
+	}
+}
+
+// appendDelete records a[x] as an element missing from b
+func (diff *Diff[T]) appendDelete(x int) {
+	diff.ed++
+	if diff.onlyEd {
+		return
+	}
+	if diff.reverse {
+		diff.ses = append(diff.ses, SesElem[T]{elem: diff.a[x], typ: SesAdd, bIdx: x + 1 + diff.ox})
+	} else {
+		diff.ses = append(diff.ses, SesElem[T]{elem: diff.a[x], typ: SesDelete, aIdx: x + 1 + diff.ox})
+	}
+}
+
+// appendAdd records b[y] as an element missing from a
+func (diff *Diff[T]) appendAdd(y int) {
+	diff.ed++
+	if diff.onlyEd {
+		return
+	}
+	if diff.reverse {
+		diff.ses = append(diff.ses, SesElem[T]{elem: diff.b[y], typ: SesDelete, aIdx: y + 1 + diff.oy})
+	} else {
+		diff.ses = append(diff.ses, SesElem[T]{elem: diff.b[y], typ: SesAdd, bIdx: y + 1 + diff.oy})
+	}
+}
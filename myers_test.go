@@ -0,0 +1,127 @@
+package gonp
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkSes verifies that ses turns a into b with consistent indices
+func checkSes[T comparable](t *testing.T, a, b []T, ses []SesElem[T]) {
+	t.Helper()
+	x, y := 0, 0
+	for i, e := range ses {
+		switch e.GetType() {
+		case SesDelete:
+			if x >= len(a) || a[x] != e.GetElem() || e.GetAIdx() != x+1 || e.GetBIdx() != 0 {
+				t.Fatalf(":%d: invalid delete %v", i, e)
+			}
+			x++
+		case SesAdd:
+			if y >= len(b) || b[y] != e.GetElem() || e.GetAIdx() != 0 || e.GetBIdx() != y+1 {
+				t.Fatalf(":%d: invalid add %v", i, e)
+			}
+			y++
+		case SesCommon:
+			if x >= len(a) || y >= len(b) || a[x] != e.GetElem() || b[y] != e.GetElem() || e.GetAIdx() != x+1 || e.GetBIdx() != y+1 {
+				t.Fatalf(":%d: invalid common %v", i, e)
+			}
+			x++
+			y++
+		}
+	}
+	if x != len(a) || y != len(b) {
+		t.Fatalf("want: %v, actual: %v", [2]int{len(a), len(b)}, [2]int{x, y})
+	}
+}
+
+func TestDiffAlgorithmLinear(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{a: "abc", b: "abd"},
+		{a: "abcdef", b: "dacfea"},
+		{a: "acbdeacbed", b: "acebdabbabed"},
+		{a: "abcbda", b: "bdcaba"},
+		{a: "", b: "abc"},
+		{a: "abc", b: ""},
+		{a: "", b: ""},
+		{a: "aaaaaaaaaa", b: "aaaaa"},
+		{a: "bokko", b: "bokkko"},
+		{a: "verdict", b: "ver"},
+	}
+
+	for _, tt := range tests {
+		onp := New([]rune(tt.a), []rune(tt.b))
+		onp.Compose()
+		linear := New([]rune(tt.a), []rune(tt.b)).SetAlgorithm(AlgorithmLinear)
+		linear.Compose()
+
+		if linear.EditDistance() != onp.EditDistance() {
+			t.Fatalf(":%s,%s: want: %v, actual: %v", tt.a, tt.b, onp.EditDistance(), linear.EditDistance())
+		}
+		if len(linear.Lcs()) != len(onp.Lcs()) {
+			t.Fatalf(":%s,%s: want: %v, actual: %v", tt.a, tt.b, string(onp.Lcs()), string(linear.Lcs()))
+		}
+		checkSes(t, []rune(tt.a), []rune(tt.b), linear.Ses())
+
+		onlyEd := New([]rune(tt.a), []rune(tt.b)).SetAlgorithm(AlgorithmLinear).OnlyEd()
+		onlyEd.Compose()
+		if onlyEd.EditDistance() != onp.EditDistance() || len(onlyEd.Ses()) != 0 {
+			t.Fatalf(":%s,%s: only ed: want: %v, actual: %v", tt.a, tt.b, onp.EditDistance(), onlyEd.EditDistance())
+		}
+	}
+}
+
+func TestDiffAlgorithmLinearRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func() []int {
+		s := make([]int, rnd.Intn(60))
+		for i := range s {
+			s[i] = rnd.Intn(4)
+		}
+		return s
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		onp := New(a, b)
+		onp.Compose()
+		linear := New(a, b).SetAlgorithm(AlgorithmLinear)
+		linear.Compose()
+
+		if linear.EditDistance() != onp.EditDistance() {
+			t.Fatalf(":%v,%v: want: %v, actual: %v", a, b, onp.EditDistance(), linear.EditDistance())
+		}
+		checkSes(t, a, b, linear.Ses())
+	}
+}
+
+func TestDiffAlgorithmLinearRouteSize(t *testing.T) {
+	a := []rune("abcdefghijklmnopqrstuvwxyz")
+	b := []rune("zyxwvutsrqponmlkjihgfedcba")
+
+	limited := New(a, b).SetRouteSize(1)
+	limited.Compose()
+	linear := New(a, b).SetRouteSize(1).SetAlgorithm(AlgorithmLinear)
+	linear.Compose()
+	unlimited := New(a, b)
+	unlimited.Compose()
+
+	if linear.EditDistance() != unlimited.EditDistance() {
+		t.Fatalf("want: %v, actual: %v", unlimited.EditDistance(), linear.EditDistance())
+	}
+	if limited.EditDistance() < linear.EditDistance() {
+		t.Fatalf("route size: want: >= %v, actual: %v", linear.EditDistance(), limited.EditDistance())
+	}
+	checkSes(t, a, b, linear.Ses())
+}
+
+func BenchmarkStringDiffComposeLinear(b *testing.B) {
+	s1 := []rune("abc")
+	s2 := []rune("abd")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		diff := New(s1, s2).SetAlgorithm(AlgorithmLinear)
+		diff.Compose()
+	}
+}
@@ -0,0 +1,143 @@
+package gonp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// sesRun is a run of changes in SES not interrupted by common elements
+type sesRun[T any] struct {
+	x, y    int // number of elements of a and b preceding the run
+	deleted []T
+	added   []T
+}
+
+// sesRuns splits SES into runs of changes
+func sesRuns[T any](ses []SesElem[T]) []sesRun[T] {
+	runs := make([]sesRun[T], 0)
+	x, y := 0, 0
+	for i := 0; i < len(ses); {
+		if ses[i].typ == SesCommon {
+			x++
+			y++
+			i++
+			continue
+		}
+
+		run := sesRun[T]{x: x, y: y}
+		for ; i < len(ses) && ses[i].typ != SesCommon; i++ {
+			switch ses[i].typ {
+			case SesDelete:
+				run.deleted = append(run.deleted, ses[i].elem)
+				x++
+			case SesAdd:
+				run.added = append(run.added, ses[i].elem)
+				y++
+			}
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// command returns the command of the run such as "3c3", "5a6,7" or "2,4d1"
+func (run *sesRun[T]) command() string {
+	oldRange := lineRange(run.x+1, run.x+len(run.deleted))
+	newRange := lineRange(run.y+1, run.y+len(run.added))
+	switch {
+	case len(run.added) == 0:
+		return fmt.Sprintf("%sd%d", oldRange, run.y)
+	case len(run.deleted) == 0:
+		return fmt.Sprintf("%da%s", run.x, newRange)
+	default:
+		return fmt.Sprintf("%sc%s", oldRange, newRange)
+	}
+}
+
+// lineRange formats range of lines like "3" or "3,5"
+func lineRange(first, last int) string {
+	if first == last {
+		return fmt.Sprint(first)
+	}
+	return fmt.Sprintf("%d,%d", first, last)
+}
+
+// PrintNormal prints normal format difference between a and b
+func (diff *Diff[T]) PrintNormal() {
+	fmt.Print(diff.SprintNormal())
+}
+
+// SprintNormal returns string about normal format difference between a and b
+func (diff *Diff[T]) SprintNormal() string {
+	var buf bytes.Buffer
+	diff.FprintNormal(&buf)
+	return buf.String()
+}
+
+// FprintNormal emit about normal format difference between a and b to w,
+// which is the default output format of POSIX diff
+func (diff *Diff[T]) FprintNormal(w io.Writer) {
+	for _, run := range sesRuns(diff.ses) {
+		fmt.Fprintf(w, "%s\n", run.command())
+		for _, e := range run.deleted {
+			fmt.Fprintf(w, "< %v\n", e)
+		}
+		if len(run.deleted) > 0 && len(run.added) > 0 {
+			fmt.Fprint(w, "---\n")
+		}
+		for _, e := range run.added {
+			fmt.Fprintf(w, "> %v\n", e)
+		}
+	}
+}
+
+// PrintEdScript prints ed script turning a into b
+func (diff *Diff[T]) PrintEdScript() {
+	fmt.Print(diff.SprintEdScript())
+}
+
+// SprintEdScript returns string about ed script turning a into b
+func (diff *Diff[T]) SprintEdScript() string {
+	var buf bytes.Buffer
+	diff.FprintEdScript(&buf)
+	return buf.String()
+}
+
+// FprintEdScript emit about ed script turning a into b to w like "diff -e".
+// Commands are written from the end of a so that line numbers stay valid.
+func (diff *Diff[T]) FprintEdScript(w io.Writer) {
+	runs := sesRuns(diff.ses)
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		oldRange := lineRange(run.x+1, run.x+len(run.deleted))
+		switch {
+		case len(run.added) == 0:
+			fmt.Fprintf(w, "%sd\n", oldRange)
+			continue
+		case len(run.deleted) == 0:
+			fmt.Fprintf(w, "%da\n", run.x)
+		default:
+			fmt.Fprintf(w, "%sc\n", oldRange)
+		}
+
+		insertMode := true
+		for _, e := range run.added {
+			if !insertMode {
+				fmt.Fprint(w, "a\n")
+				insertMode = true
+			}
+			line := fmt.Sprint(e)
+			if line == "." {
+				// a single dot ends insert mode, so write it doubled and remove the extra one
+				fmt.Fprint(w, "..\n.\ns/.//\n")
+				insertMode = false
+				continue
+			}
+			fmt.Fprintf(w, "%s\n", line)
+		}
+		if insertMode {
+			fmt.Fprint(w, ".\n")
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package gonp
+
+import (
+	"testing"
+)
+
+func TestDiffSprintNormal(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []string
+		expected string
+	}{
+		{
+			name: "change",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "1", "c"},
+			expected: `2c2
+< b
+---
+> 1
+`,
+		},
+		{
+			name: "add and delete",
+			a:    []string{"a", "b", "c", "d", "e"},
+			b:    []string{"x", "y", "a", "b", "d", "e"},
+			expected: `0a1,2
+> x
+> y
+3d4
+< c
+`,
+		},
+		{
+			name: "multiple lines",
+			a:    []string{"a", "b", "c", "d"},
+			b:    []string{"a", "x", "y", "z"},
+			expected: `2,4c2,4
+< b
+< c
+< d
+---
+> x
+> y
+> z
+`,
+		},
+		{
+			name:     "same",
+			a:        []string{"a"},
+			b:        []string{"a"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		diff := New(tt.a, tt.b)
+		diff.Compose()
+		if actual := diff.SprintNormal(); actual != tt.expected {
+			t.Fatalf(":%s: want: %v, actual: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestDiffSprintEdScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []string
+		expected string
+	}{
+		{
+			name: "add and delete",
+			a:    []string{"a", "b", "c", "d", "e"},
+			b:    []string{"x", "y", "a", "b", "d", "E"},
+			expected: `5c
+E
+.
+3d
+0a
+x
+y
+.
+`,
+		},
+		{
+			name: "dot",
+			a:    []string{"a"},
+			b:    []string{"a", ".", "b"},
+			expected: `1a
+..
+.
+s/.//
+a
+b
+.
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		diff := New(tt.a, tt.b)
+		diff.Compose()
+		if actual := diff.SprintEdScript(); actual != tt.expected {
+			t.Fatalf(":%s: want: %v, actual: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
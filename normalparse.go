@@ -0,0 +1,252 @@
+package gonp
+
+import (
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	normalCommand = regexp.MustCompile(`^(\d+)(?:,(\d+))?([acd])(\d+)(?:,(\d+))?$`)
+	edCommand     = regexp.MustCompile(`^(\d+)(?:,(\d+))?([acd])$`)
+)
+
+// maxEdRange limits lines deleted by an ed command,
+// since they are not written in ed script but made up as empty elements
+const maxEdRange = 1 << 20
+
+// parseLineRange parses "first" or "first,last" into the first line and the number of lines
+func parseLineRange(lr *lineReader, first, last string) (int, int, error) {
+	f, err := strconv.Atoi(first)
+	if err != nil {
+		return 0, 0, lr.errorf("invalid line number %q", first)
+	}
+	if last == "" {
+		return f, 1, nil
+	}
+	l, err := strconv.Atoi(last)
+	if err != nil || l < f {
+		return 0, 0, lr.errorf("invalid line range %s,%s", first, last)
+	}
+	return f, l - f + 1, nil
+}
+
+// ParseNormal parses normal format difference written by FprintNormal or POSIX diff.
+// Each command becomes UniHunk without context.
+func ParseNormal(r io.Reader) ([]UniHunk[string], error) {
+	lr := newLineReader(r)
+	uniHunks := make([]UniHunk[string], 0)
+	for {
+		text, ok := lr.next()
+		if !ok {
+			break
+		}
+
+		m := normalCommand.FindStringSubmatch(text)
+		if m == nil {
+			return nil, lr.errorf("invalid command %q", text)
+		}
+		a, b, err := parseLineRange(lr, m[1], m[2])
+		if err != nil {
+			return nil, err
+		}
+		c, d, err := parseLineRange(lr, m[4], m[5])
+		if err != nil {
+			return nil, err
+		}
+		switch m[3] {
+		case "a":
+			if m[2] != "" {
+				return nil, lr.errorf("invalid command %q", text)
+			}
+			b = 0
+		case "d":
+			if m[5] != "" {
+				return nil, lr.errorf("invalid command %q", text)
+			}
+			d = 0
+		}
+
+		uniHunk := UniHunk[string]{a: a, b: b, c: c, d: d}
+		if err := parseNormalLines(lr, &uniHunk, "< ", SesDelete, b); err != nil {
+			return nil, err
+		}
+		if b > 0 && d > 0 {
+			if text, ok := lr.next(); !ok || text != "---" {
+				return nil, lr.errorf(`"---" is expected`)
+			}
+		}
+		if err := parseNormalLines(lr, &uniHunk, "> ", SesAdd, d); err != nil {
+			return nil, err
+		}
+		uniHunks = append(uniHunks, uniHunk)
+	}
+	if lr.lastErr != nil {
+		return nil, lr.lastErr
+	}
+
+	return uniHunks, nil
+}
+
+// parseNormalLines reads n lines starting with prefix into changes of uniHunk
+func parseNormalLines(lr *lineReader, uniHunk *UniHunk[string], prefix string, typ SesType, n int) error {
+	for i := 0; i < n; i++ {
+		text, ok := lr.next()
+		if !ok {
+			return lr.errorf("unexpected end of difference, %d lines are missing", n-i)
+		}
+		if strings.HasPrefix(text, `\`) {
+			// "\ No newline at end of file"
+			uniHunk.addNote(text)
+			i--
+			continue
+		}
+		elem, ok := strings.CutPrefix(text, prefix)
+		if !ok {
+			return lr.errorf("line starting with %q is expected", prefix)
+		}
+
+		e := SesElem[string]{elem: elem, typ: typ}
+		if typ == SesDelete {
+			e.aIdx = uniHunk.a + i
+		} else {
+			e.bIdx = uniHunk.c + i
+		}
+		uniHunk.changes = append(uniHunk.changes, e)
+	}
+
+	if text, ok := lr.peek(); ok && strings.HasPrefix(text, `\`) {
+		lr.next()
+		uniHunk.addNote(text)
+	}
+
+	return nil
+}
+
+// parseEdScript parses ed script written by FprintEdScript or "diff -e".
+// Each command becomes UniHunk without context, ordered from the beginning of the file.
+// Since ed script does not contain deleted lines, they are made up as empty elements,
+// so that the hunks are only for UniPatch by PatchEdScript and never returned.
+func parseEdScript(r io.Reader) ([]UniHunk[string], error) {
+	lr := newLineReader(r)
+	reversed := make([]UniHunk[string], 0)
+	for {
+		text, ok := lr.next()
+		if !ok {
+			break
+		}
+
+		m := edCommand.FindStringSubmatch(text)
+		if m == nil {
+			return nil, lr.errorf("invalid command %q", text)
+		}
+		a, b, err := parseLineRange(lr, m[1], m[2])
+		if err != nil {
+			return nil, err
+		}
+		if m[3] == "a" {
+			if m[2] != "" {
+				return nil, lr.errorf("invalid command %q", text)
+			}
+			b = 0
+		}
+		if b > maxEdRange {
+			return nil, lr.errorf("too many lines in range %s,%s", m[1], m[2])
+		}
+		if n := len(reversed); n > 0 && a+b > reversed[n-1].a {
+			return nil, lr.errorf("commands must be ordered from the end of the file")
+		}
+
+		uniHunk := UniHunk[string]{a: a, b: b}
+		for i := 0; i < b; i++ {
+			uniHunk.changes = append(uniHunk.changes, SesElem[string]{typ: SesDelete, aIdx: a + i})
+		}
+		if m[3] != "d" {
+			added, err := parseEdText(lr)
+			if err != nil {
+				return nil, err
+			}
+			for _, elem := range added {
+				uniHunk.changes = append(uniHunk.changes, SesElem[string]{elem: elem, typ: SesAdd})
+			}
+			uniHunk.d = len(added)
+		}
+		reversed = append(reversed, uniHunk)
+	}
+	if lr.lastErr != nil {
+		return nil, lr.lastErr
+	}
+
+	// number lines of b from the beginning of the file
+	uniHunks := make([]UniHunk[string], 0, len(reversed))
+	delta := 0
+	for i := len(reversed) - 1; i >= 0; i-- {
+		h := reversed[i]
+		h.c = hunkStart(h) + delta + 1
+		if h.d == 0 {
+			h.c--
+		}
+		y := h.c
+		for j := range h.changes {
+			if h.changes[j].typ == SesAdd {
+				h.changes[j].bIdx = y
+				y++
+			}
+		}
+		delta += h.d - h.b
+		uniHunks = append(uniHunks, h)
+	}
+
+	return uniHunks, nil
+}
+
+// parseEdText reads lines to insert until ".",
+// following "s/.//" and "a" commands written for lines consisting of a single dot
+func parseEdText(lr *lineReader) ([]string, error) {
+	lines := make([]string, 0)
+	for {
+		text, ok := lr.next()
+		if !ok {
+			return nil, lr.errorf(`unexpected end of script, "." is expected`)
+		}
+		if text != "." {
+			lines = append(lines, text)
+			continue
+		}
+
+		if next, ok := lr.peek(); !ok || next != "s/.//" {
+			return lines, nil
+		}
+		lr.next()
+		if len(lines) == 0 || lines[len(lines)-1] == "" {
+			return nil, lr.errorf("no character to substitute")
+		}
+		lines[len(lines)-1] = lines[len(lines)-1][1:]
+		if next, ok := lr.peek(); !ok || next != "a" {
+			return lines, nil
+		}
+		lr.next()
+	}
+}
+
+// PatchNormal applies normal format difference read from r to seq,
+// verifying that deleted lines match seq
+func PatchNormal(seq []string, r io.Reader) ([]string, error) {
+	uniHunks, err := ParseNormal(r)
+	if err != nil {
+		return []string{}, err
+	}
+	return New[string](nil, nil).UniPatchStrict(seq, uniHunks)
+}
+
+// PatchEdScript applies ed script read from r to seq.
+// Unlike normal format difference, ed script is not parsed into hunks,
+// since it does not contain deleted lines needed to print, invert or locate hunks.
+func PatchEdScript(seq []string, r io.Reader) ([]string, error) {
+	uniHunks, err := parseEdScript(r)
+	if err != nil {
+		return []string{}, err
+	}
+	return New[string](nil, nil).UniPatch(seq, uniHunks)
+}
@@ -0,0 +1,127 @@
+package gonp
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPatchNormalAndEdScript(t *testing.T) {
+	tests := []struct {
+		a string
+		b string
+	}{
+		{a: "", b: ""},
+		{a: "", b: "def"},
+		{a: "abc", b: ""},
+		{a: "abc", b: "abd"},
+		{a: "abcdef", b: "dacfea"},
+		{a: "acbdeacbed", b: "acebdabbabed"},
+		{a: "abcbda", b: "bdcaba"},
+		{a: "a.b", b: ".a..b."},
+	}
+
+	for _, tt := range tests {
+		a, b := split(tt.a), split(tt.b)
+		diff := New(a, b)
+		diff.Compose()
+
+		patched, err := PatchNormal(a, strings.NewReader(diff.SprintNormal()))
+		if err != nil {
+			t.Fatalf("normal '%s' and '%s': unexpected error: %v", tt.a, tt.b, err)
+		}
+		if !slices.Equal(b, patched) {
+			t.Fatalf("normal '%s' and '%s': want: %v, got: %v", tt.a, tt.b, b, patched)
+		}
+
+		patched, err = PatchEdScript(a, strings.NewReader(diff.SprintEdScript()))
+		if err != nil {
+			t.Fatalf("ed '%s' and '%s': unexpected error: %v", tt.a, tt.b, err)
+		}
+		if !slices.Equal(b, patched) {
+			t.Fatalf("ed '%s' and '%s': want: %v, got: %v", tt.a, tt.b, b, patched)
+		}
+	}
+}
+
+func TestParseNormal(t *testing.T) {
+	text := `0a1
+> x
+3,4c4
+< c
+< d
+---
+> y
+6d5
+< f
+`
+	uniHunks, err := ParseNormal(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `@@ -0,0 +1,1 @@
++x
+@@ -3,2 +4,1 @@
+-c
+-d
++y
+@@ -6,1 +5,0 @@
+-f
+`
+	if actual := SprintUniHunks(uniHunks); actual != expected {
+		t.Fatalf("want: %v, got: %v", expected, actual)
+	}
+
+	// the same hunks are built from ed script
+	uniHunks, err = parseEdScript(strings.NewReader("6d\n3,4c\ny\n.\n0a\nx\n.\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = `@@ -0,0 +1,1 @@
++x
+@@ -3,2 +4,1 @@
+-
+-
++y
+@@ -6,1 +5,0 @@
+-
+`
+	if actual := SprintUniHunks(uniHunks); actual != expected {
+		t.Fatalf("want: %v, got: %v", expected, actual)
+	}
+}
+
+func TestParseNormalError(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		ed   bool
+		line int
+	}{
+		{name: "invalid command", text: "1x1\n", line: 1},
+		{name: "missing separator", text: "1c1\n< a\n> b\n", line: 3},
+		{name: "missing line", text: "1,2d0\n< a\n", line: 2},
+		{name: "wrong prefix", text: "0a1\n< a\n", line: 2},
+		{name: "ed invalid command", text: "1x\n", ed: true, line: 1},
+		{name: "ed unordered", text: "1d\n3d\n", ed: true, line: 2},
+		{name: "ed unterminated", text: "1a\nx\n", ed: true, line: 2},
+		{name: "ed huge range", text: "1,3000000000d\n", ed: true, line: 1},
+	}
+
+	for _, tt := range tests {
+		var err error
+		if tt.ed {
+			_, err = parseEdScript(strings.NewReader(tt.text))
+		} else {
+			_, err = ParseNormal(strings.NewReader(tt.text))
+		}
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf(":%s: want SyntaxError, got: %v", tt.name, err)
+		}
+		if se.Line != tt.line {
+			t.Fatalf(":%s:line: want: %d, got: %d (%v)", tt.name, tt.line, se.Line, se)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package gonp
+
+// OpTag is a tag of Opcode
+type OpTag int
+
+const (
+	// OpEqual means a[I1:I2] == b[J1:J2]
+	OpEqual OpTag = iota
+	// OpReplace means a[I1:I2] should be replaced by b[J1:J2]
+	OpReplace
+	// OpDelete means a[I1:I2] should be deleted, J1 == J2
+	OpDelete
+	// OpInsert means b[J1:J2] should be inserted at a[I1:I1], I1 == I2
+	OpInsert
+)
+
+// String returns the tag name used by Python difflib
+func (tag OpTag) String() string {
+	switch tag {
+	case OpEqual:
+		return "equal"
+	case OpReplace:
+		return "replace"
+	case OpDelete:
+		return "delete"
+	case OpInsert:
+		return "insert"
+	}
+	return "unknown"
+}
+
+// Opcode is an operation turning a[I1:I2] into b[J1:J2] like SequenceMatcher.get_opcodes() of Python difflib.
+// Indices are 0-origin and ranges are half-open.
+type Opcode struct {
+	Tag            OpTag
+	I1, I2, J1, J2 int
+}
+
+// Opcodes returns operations turning a into b, derived from SES
+func (diff *Diff[T]) Opcodes() []Opcode {
+	return sesOpcodes(diff.ses, 0, 0)
+}
+
+// GroupedOpcodes returns groups of operations with n elements of context,
+// grouped as the hunks of UnifiedHunks with SetContextSize(n)
+func (diff *Diff[T]) GroupedOpcodes(n int) [][]Opcode {
+	groups := make([][]Opcode, 0)
+	for _, uniHunk := range diff.unifiedHunks(n) {
+		i, j := uniHunk.a-1, uniHunk.c-1
+		if uniHunk.b == 0 {
+			i++
+		}
+		if uniHunk.d == 0 {
+			j++
+		}
+		groups = append(groups, sesOpcodes(uniHunk.changes, i, j))
+	}
+	return groups
+}
+
+// sesOpcodes returns operations of ses starting at a[i] and b[j]
+func sesOpcodes[T any](ses []SesElem[T], i, j int) []Opcode {
+	opcodes := make([]Opcode, 0)
+	for k := 0; k < len(ses); {
+		op := Opcode{I1: i, J1: j}
+		if ses[k].typ == SesCommon {
+			for ; k < len(ses) && ses[k].typ == SesCommon; k++ {
+				i++
+				j++
+			}
+			op.Tag = OpEqual
+		} else {
+			for ; k < len(ses) && ses[k].typ != SesCommon; k++ {
+				if ses[k].typ == SesDelete {
+					i++
+				} else {
+					j++
+				}
+			}
+			switch {
+			case i == op.I1:
+				op.Tag = OpInsert
+			case j == op.J1:
+				op.Tag = OpDelete
+			default:
+				op.Tag = OpReplace
+			}
+		}
+		op.I2, op.J2 = i, j
+		opcodes = append(opcodes, op)
+	}
+	return opcodes
+}
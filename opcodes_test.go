@@ -0,0 +1,133 @@
+package gonp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffOpcodes(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected []Opcode
+	}{
+		{
+			// example of Python difflib
+			a: "qabxcd",
+			b: "abycdf",
+			expected: []Opcode{
+				{Tag: OpDelete, I1: 0, I2: 1, J1: 0, J2: 0},
+				{Tag: OpEqual, I1: 1, I2: 3, J1: 0, J2: 2},
+				{Tag: OpReplace, I1: 3, I2: 4, J1: 2, J2: 3},
+				{Tag: OpEqual, I1: 4, I2: 6, J1: 3, J2: 5},
+				{Tag: OpInsert, I1: 6, I2: 6, J1: 5, J2: 6},
+			},
+		},
+		{
+			a:        "abc",
+			b:        "abc",
+			expected: []Opcode{{Tag: OpEqual, I1: 0, I2: 3, J1: 0, J2: 3}},
+		},
+		{
+			a:        "",
+			b:        "",
+			expected: []Opcode{},
+		},
+	}
+
+	for _, tt := range tests {
+		diff := New([]rune(tt.a), []rune(tt.b))
+		diff.Compose()
+		if actual := diff.Opcodes(); !reflect.DeepEqual(actual, tt.expected) {
+			t.Fatalf(":%s,%s: want: %v, actual: %v", tt.a, tt.b, tt.expected, actual)
+		}
+	}
+}
+
+func TestDiffGroupedOpcodes(t *testing.T) {
+	a := make([]int, 0, 20)
+	for i := 1; i <= 20; i++ {
+		a = append(a, i)
+	}
+	b := make([]int, len(a))
+	copy(b, a)
+	b[1] = 0
+	b = append(b[:14], b[15:]...)
+
+	diff := New(a, b)
+	diff.Compose()
+	groups := diff.GroupedOpcodes(3)
+	expected := [][]Opcode{
+		{
+			{Tag: OpEqual, I1: 0, I2: 1, J1: 0, J2: 1},
+			{Tag: OpReplace, I1: 1, I2: 2, J1: 1, J2: 2},
+			{Tag: OpEqual, I1: 2, I2: 5, J1: 2, J2: 5},
+		},
+		{
+			{Tag: OpEqual, I1: 11, I2: 14, J1: 11, J2: 14},
+			{Tag: OpDelete, I1: 14, I2: 15, J1: 14, J2: 14},
+			{Tag: OpEqual, I1: 15, I2: 18, J1: 14, J2: 17},
+		},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Fatalf("want: %v, actual: %v", expected, groups)
+	}
+
+	// ranges of groups match hunks of UnifiedHunks
+	for i, uniHunk := range diff.UnifiedHunks() {
+		group := groups[i]
+		r := uniHunk.GetRange()
+		first, last := group[0], group[len(group)-1]
+		if first.I1+1 != r.OldStart || last.I2-first.I1 != r.OldLines || first.J1+1 != r.NewStart || last.J2-first.J1 != r.NewLines {
+			t.Fatalf(":%d: want: %v, actual: %v", i, r, group)
+		}
+	}
+
+	// groups without context like difflib with n=0
+	tests := []struct {
+		a, b     string
+		expected [][]Opcode
+	}{
+		{
+			a:        "ab",
+			b:        "abx",
+			expected: [][]Opcode{{{Tag: OpInsert, I1: 2, I2: 2, J1: 2, J2: 3}}},
+		},
+		{
+			a:        "abx",
+			b:        "ab",
+			expected: [][]Opcode{{{Tag: OpDelete, I1: 2, I2: 3, J1: 2, J2: 2}}},
+		},
+		{
+			a: "xabcdefgh",
+			b: "abcdefghy",
+			expected: [][]Opcode{
+				{{Tag: OpDelete, I1: 0, I2: 1, J1: 0, J2: 0}},
+				{{Tag: OpInsert, I1: 9, I2: 9, J1: 8, J2: 9}},
+			},
+		},
+		{
+			a: "abcdef",
+			b: "aXcdYf",
+			expected: [][]Opcode{
+				{{Tag: OpReplace, I1: 1, I2: 2, J1: 1, J2: 2}},
+				{{Tag: OpReplace, I1: 4, I2: 5, J1: 4, J2: 5}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		diff := New([]rune(tt.a), []rune(tt.b))
+		diff.Compose()
+		if actual := diff.GroupedOpcodes(0); !reflect.DeepEqual(actual, tt.expected) {
+			t.Fatalf(":%s,%s: want: %v, actual: %v", tt.a, tt.b, tt.expected, actual)
+		}
+	}
+}
+
+func TestOpTagString(t *testing.T) {
+	for tag, expected := range map[OpTag]string{OpEqual: "equal", OpReplace: "replace", OpDelete: "delete", OpInsert: "insert"} {
+		if actual := tag.String(); actual != expected {
+			t.Fatalf("want: %v, actual: %v", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package gonp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var uniHunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// maxPreallocLines limits lines of a hunk allocated in advance from its header
+const maxPreallocLines = 1024
+
+// SyntaxError is returned when a patch text can not be parsed
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// lineReader reads a patch text line by line, keeping track of line numbers
+type lineReader struct {
+	r       *bufio.Reader
+	line    int
+	peeked  bool
+	text    string
+	nl      bool // whether the last line read ends with a newline
+	eof     bool
+	lastErr error
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{r: bufio.NewReader(r)}
+}
+
+// peek returns the next line without consuming it
+func (lr *lineReader) peek() (text string, ok bool) {
+	if lr.peeked {
+		return lr.text, !lr.eof
+	}
+	lr.peeked = true
+	s, err := lr.r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		lr.lastErr = err
+	}
+	if s == "" {
+		lr.eof = true
+		return "", false
+	}
+	lr.nl = strings.HasSuffix(s, "\n")
+	lr.text = strings.TrimSuffix(s, "\n")
+	return lr.text, true
+}
+
+// next consumes the next line
+func (lr *lineReader) next() (text string, ok bool) {
+	text, ok = lr.peek()
+	if ok {
+		lr.peeked = false
+		lr.line++
+	}
+	return text, ok
+}
+
+func (lr *lineReader) errorf(format string, args ...any) error {
+	return &SyntaxError{Line: lr.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+// ParseUniHunks parses unified format difference written by FprintUniHunks
+func ParseUniHunks(r io.Reader) ([]UniHunk[string], error) {
+	lr := newLineReader(r)
+	uniHunks := make([]UniHunk[string], 0)
+	for {
+		text, ok := lr.next()
+		if !ok {
+			break
+		}
+		uniHunk, err := parseUniHunk(lr, text)
+		if err != nil {
+			return nil, err
+		}
+		uniHunks = append(uniHunks, uniHunk)
+	}
+	if lr.lastErr != nil {
+		return nil, lr.lastErr
+	}
+
+	return uniHunks, nil
+}
+
+// parseUniHunkHeader parses "@@ -a,b +c,d @@ section" into a, b, c, d and section
+func parseUniHunkHeader(header string) (rng [4]int, section string, err error) {
+	m := uniHunkHeader.FindStringSubmatch(header)
+	if m == nil {
+		return rng, "", fmt.Errorf("invalid hunk header %q", header)
+	}
+
+	for i, s := range []string{m[1], m[2], m[3], m[4]} {
+		if s == "" {
+			// omitted length means a single line
+			rng[i] = 1
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return rng, "", fmt.Errorf("invalid hunk range %q", s)
+		}
+		rng[i] = n
+	}
+	return rng, m[5], nil
+}
+
+// parseUniHunk parses a hunk whose header has already been consumed from lr
+func parseUniHunk(lr *lineReader, header string) (UniHunk[string], error) {
+	rng, section, err := parseUniHunkHeader(header)
+	if err != nil {
+		return UniHunk[string]{}, lr.errorf("%s", err)
+	}
+
+	// lengths in header are not trusted until lines are read
+	capacity := min(max(rng[1], rng[3]), maxPreallocLines)
+
+	uniHunk := UniHunk[string]{
+		a: rng[0], b: rng[1], c: rng[2], d: rng[3],
+		section: section,
+		header:  header,
+		changes: make([]SesElem[string], 0, capacity),
+	}
+
+	x, y := uniHunk.a, uniHunk.c
+	restA, restB := uniHunk.b, uniHunk.d
+	for restA > 0 || restB > 0 {
+		text, ok := lr.next()
+		if !ok {
+			return UniHunk[string]{}, lr.errorf("unexpected end of hunk, %d old and %d new lines are missing", restA, restB)
+		}
+
+		op, elem := byte(' '), ""
+		if text != "" {
+			op, elem = text[0], text[1:]
+		}
+		switch op {
+		case ' ':
+			if restA == 0 || restB == 0 {
+				return UniHunk[string]{}, lr.errorf("too many common lines in hunk")
+			}
+			uniHunk.changes = append(uniHunk.changes, SesElem[string]{elem: elem, typ: SesCommon, aIdx: x, bIdx: y})
+			x++
+			y++
+			restA--
+			restB--
+		case '-':
+			if restA == 0 {
+				return UniHunk[string]{}, lr.errorf("too many deleted lines in hunk")
+			}
+			uniHunk.changes = append(uniHunk.changes, SesElem[string]{elem: elem, typ: SesDelete, aIdx: x, bIdx: 0})
+			x++
+			restA--
+		case '+':
+			if restB == 0 {
+				return UniHunk[string]{}, lr.errorf("too many added lines in hunk")
+			}
+			uniHunk.changes = append(uniHunk.changes, SesElem[string]{elem: elem, typ: SesAdd, aIdx: 0, bIdx: y})
+			y++
+			restB--
+		case '\\':
+			uniHunk.addNote(text)
+		default:
+			return UniHunk[string]{}, lr.errorf("unexpected line in hunk %q", text)
+		}
+	}
+
+	// marker may follow the last line of hunk
+	if text, ok := lr.peek(); ok && strings.HasPrefix(text, `\`) {
+		lr.next()
+		uniHunk.addNote(text)
+	}
+
+	return uniHunk, nil
+}
+
+// addNote attaches a "\ No newline at end of file" line to the last change
+func (uniHunk *UniHunk[T]) addNote(text string) {
+	if len(uniHunk.changes) == 0 {
+		return
+	}
+	if uniHunk.notes == nil {
+		uniHunk.notes = make(map[int]string)
+	}
+	uniHunk.notes[len(uniHunk.changes)-1] = text
+}
@@ -0,0 +1,157 @@
+package gonp
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseUniHunks(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+	}{
+		{
+			name: "single hunk",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "1", "c"},
+		},
+		{
+			name: "plural hunks",
+			a:    strings.Split("abcaaaaaabd", ""),
+			b:    strings.Split("abdaaaaaabc", ""),
+		},
+		{
+			name: "add to empty",
+			a:    []string{},
+			b:    []string{"a", "b"},
+		},
+		{
+			name: "delete all",
+			a:    []string{"a", "b"},
+			b:    []string{},
+		},
+		{
+			name: "empty lines",
+			a:    []string{"", "a", ""},
+			b:    []string{"", "b", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		diff := New(tt.a, tt.b)
+		diff.Compose()
+		uniHunks := diff.UnifiedHunks()
+
+		parsed, err := ParseUniHunks(strings.NewReader(SprintUniHunks(uniHunks)))
+		if err != nil {
+			t.Fatalf(":%s: unexpected error: %v", tt.name, err)
+		}
+		if !equalsUniHunks(uniHunks, parsed, cmp.Compare) {
+			t.Fatalf(":%s:uniHunks: want: %v, got: %v", tt.name, uniHunks, parsed)
+		}
+
+		patched, err := New[string](nil, nil).UniPatch(tt.a, parsed)
+		if err != nil {
+			t.Fatalf(":%s: unexpected error: %v", tt.name, err)
+		}
+		if !slices.Equal(tt.b, patched) {
+			t.Fatalf(":%s:patched: want: %v, got: %v", tt.name, tt.b, patched)
+		}
+	}
+}
+
+func TestParseUniHunksShortRange(t *testing.T) {
+	text := `@@ -2 +2,2 @@ func main() {
+-b
++c
++d
+\ No newline at end of file
+`
+	uniHunks, err := ParseUniHunks(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []UniHunk[string]{
+		{a: 2, b: 1, c: 2, d: 2, section: "func main() {",
+			changes: []SesElem[string]{
+				{elem: "b", typ: SesDelete, aIdx: 2, bIdx: 0},
+				{elem: "c", typ: SesAdd, aIdx: 0, bIdx: 2},
+				{elem: "d", typ: SesAdd, aIdx: 0, bIdx: 3},
+			},
+		},
+	}
+	if !equalsUniHunks(expected, uniHunks, cmp.Compare) {
+		t.Fatalf("want: %v, got: %v", expected, uniHunks)
+	}
+	if uniHunks[0].section != "func main() {" {
+		t.Fatalf("section: want: %q, got: %q", "func main() {", uniHunks[0].section)
+	}
+	if actual := SprintUniHunks(uniHunks); actual != text {
+		t.Fatalf("round trip: want: %v, actual: %v", text, actual)
+	}
+}
+
+func TestParseUniHunksError(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		line int
+	}{
+		{
+			name: "invalid header",
+			text: "@@ -1,1 @@\n a\n",
+			line: 1,
+		},
+		{
+			name: "garbage outside hunk",
+			text: "@@ -1,1 +1,1 @@\n a\nfoo\n",
+			line: 3,
+		},
+		{
+			name: "unexpected line in hunk",
+			text: "@@ -1,2 +1,2 @@\n a\n*b\n",
+			line: 3,
+		},
+		{
+			name: "too many added lines",
+			text: "@@ -1,1 +1,1 @@\n-a\n+b\n+c\n",
+			line: 4,
+		},
+		{
+			name: "truncated hunk",
+			text: "@@ -1,3 +1,3 @@\n a\n b\n",
+			line: 3,
+		},
+		{
+			name: "huge length",
+			text: "@@ -1,900000000000000000 +1,1 @@\n a\n",
+			line: 2,
+		},
+		{
+			name: "huge length without lines",
+			text: "@@ -1,2000000000 +1,1 @@\n",
+			line: 1,
+		},
+		{
+			name: "length out of range",
+			text: "@@ -1,99999999999999999999 +1,1 @@\n a\n",
+			line: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseUniHunks(strings.NewReader(tt.text))
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf(":%s: want SyntaxError, got: %v", tt.name, err)
+		}
+		if se.Line != tt.line {
+			t.Fatalf(":%s:line: want: %d, got: %d (%v)", tt.name, tt.line, se.Line, se)
+		}
+	}
+}
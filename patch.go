@@ -1,106 +1,93 @@
 package gonp
 
-import (
-	"container/list"
-	"fmt"
-)
-
-// Patch applies SES between a and b to seq
-func (diff *Diff[T]) Patch(seq []T) []T {
+// Patch applies SES between a and b to seq.
+// Elements of seq are not compared with SES, use PatchStrict to verify them.
+func (diff *Diff[T]) Patch(seq []T) ([]T, error) {
+	if diff.err != nil {
+		return []T{}, diff.err
+	}
 	if diff.ed == 0 {
-		return seq
+		return seq, nil
 	}
+	return patchSes(seq, diff.ses, diff.cmp, false)
+}
 
-	l := list.New()
-	for _, e := range seq {
-		l.PushBack(e)
+// PatchStrict applies SES between a and b to seq
+// verifying that every common and deleted element matches seq
+func (diff *Diff[T]) PatchStrict(seq []T) ([]T, error) {
+	if diff.err != nil {
+		return []T{}, diff.err
 	}
+	return patchSes(seq, diff.ses, diff.cmp, true)
+}
 
-	le := l.Front()
-	for _, e := range diff.ses {
-		switch e.typ {
-		case SesDelete:
-			lea := le.Next()
-			l.Remove(le)
-			le = lea
-		case SesAdd:
-			if le == nil {
-				le = l.PushBack(e.elem)
-				le = le.Next()
-			} else {
-				l.InsertBefore(e.elem, le)
-			}
-		case SesCommon:
-			le = le.Next()
+// patchSes applies ses to seq, comparing elements with cmp if strict is true
+func patchSes[T any](seq []T, ses []SesElem[T], cmp func(T, T) int, strict bool) ([]T, error) {
+	r := make([]T, 0, len(seq))
+	pos := 0
+	for i, e := range ses {
+		if e.typ == SesAdd {
+			r = append(r, e.elem)
+			continue
 		}
-	}
 
-	r := make([]T, 0, l.Len())
-	for e := l.Front(); e != nil; e = e.Next() {
-		r = append(r, e.Value.(T))
+		if pos >= len(seq) {
+			return []T{}, &HunkOutOfRangeError{Index: i, Pos: pos + 1, Len: len(seq)}
+		}
+		if strict && cmp(seq[pos], e.elem) != 0 {
+			return []T{}, &ContextMismatchError[T]{Index: i, Pos: pos + 1, Expected: e.elem, Actual: seq[pos]}
+		}
+		if e.typ == SesCommon {
+			r = append(r, seq[pos])
+		}
+		pos++
+	}
+	if pos != len(seq) {
+		if strict {
+			return []T{}, &LengthMismatchError{Expected: pos, Actual: len(seq)}
+		}
+		r = append(r, seq[pos:]...)
 	}
 
-	return r
+	return r, nil
 }
 
-// UniPatch applies unified format difference between a and b to seq
+// UniPatch applies unified format difference between a and b to seq.
+// Elements of seq are not compared with hunks, use UniPatchStrict to verify them.
 func (diff *Diff[T]) UniPatch(seq []T, uniHunks []UniHunk[T]) ([]T, error) {
-	if diff.ed == 0 {
-		return seq, nil
+	if diff.err != nil {
+		return []T{}, diff.err
 	}
 	if len(uniHunks) == 0 {
-		return []T{}, fmt.Errorf("invalid difference")
-	}
-
-	l := list.New()
-	for _, e := range seq {
-		l.PushBack(e)
+		if diff.ed == 0 {
+			return seq, nil
+		}
+		return []T{}, &EmptyPatchError{}
 	}
 
-	le := l.Front()
-	for i := 0; i < uniHunks[0].a-1; i++ {
-		if le == nil {
-			return []T{}, fmt.Errorf("invalid difference")
+	ap := newHunkApplier(seq, diff.cmp)
+	for i, h := range uniHunks {
+		pos := hunkStart(h)
+		if err := ap.checkRange(h, i+1, pos); err != nil {
+			return []T{}, err
 		}
-		le = le.Next()
+		ap.replace(h.changes, pos)
 	}
 
-	p := 0
-	for _, h := range uniHunks {
-		if p != 0 {
-			for i := 0; i < h.a-p-1; i++ {
-				if le == nil {
-					return []T{}, fmt.Errorf("invalid difference")
-				}
-				le = le.Next()
-			}
-		}
+	return ap.finish(), nil
+}
 
-		for _, e := range h.changes {
-			switch e.typ {
-			case SesDelete:
-				lea := le.Next()
-				l.Remove(le)
-				le = lea
-			case SesAdd:
-				if le == nil {
-					le = l.PushBack(e.elem)
-					le = le.Next()
-				} else {
-					l.InsertBefore(e.elem, le)
-				}
-			case SesCommon:
-				le = le.Next()
-			}
+// UniPatchStrict applies unified format difference to seq
+// verifying that every common and deleted element matches seq
+func (diff *Diff[T]) UniPatchStrict(seq []T, uniHunks []UniHunk[T]) ([]T, error) {
+	ap := newHunkApplier(seq, diff.cmp)
+	for i, h := range uniHunks {
+		pos := hunkStart(h)
+		if err := ap.verify(h, i+1, pos); err != nil {
+			return []T{}, err
 		}
-
-		p = h.a + h.b - 1
+		ap.replace(h.changes, pos)
 	}
 
-	r := make([]T, 0, l.Len())
-	for e := l.Front(); e != nil; e = e.Next() {
-		r = append(r, e.Value.(T))
-	}
-
-	return r, nil
+	return ap.finish(), nil
 }
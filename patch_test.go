@@ -1,6 +1,7 @@
 package gonp
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"unicode/utf8"
@@ -84,7 +85,10 @@ func TestPatch(t *testing.T) {
 		diff := New([]rune(test.a), []rune(test.b))
 		diff.Compose()
 
-		patchedSeq := diff.Patch([]rune(test.a))
+		patchedSeq, err := diff.Patch([]rune(test.a))
+		if err != nil {
+			t.Fatalf("applying SES between '%s' and '%s': unexpected error: %v", test.a, test.b, err)
+		}
 		if string(patchedSeq) != test.b {
 			t.Errorf("applying SES between '%s' and '%s' to '%s' is %s, but got %s", string(test.a), string(test.b), string(test.a), string(test.b), string(patchedSeq))
 		}
@@ -96,6 +100,47 @@ func TestPatch(t *testing.T) {
 	}
 }
 
+func TestPatchError(t *testing.T) {
+	diff := New([]rune("abcd"), []rune("axcd"))
+	diff.Compose()
+
+	_, err := diff.Patch([]rune("ab"))
+	var oore *HunkOutOfRangeError
+	if !errors.As(err, &oore) {
+		t.Fatalf("want: HunkOutOfRangeError, got: %v", err)
+	}
+	if expected := (HunkOutOfRangeError{Index: 3, Pos: 3, Len: 2}); *oore != expected {
+		t.Fatalf("want: %+v, got: %+v", expected, *oore)
+	}
+
+	_, err = diff.PatchStrict([]rune("abcde"))
+	var lme *LengthMismatchError
+	if !errors.As(err, &lme) || lme.Expected != 4 || lme.Actual != 5 {
+		t.Fatalf("want: LengthMismatchError, got: %v", err)
+	}
+
+	_, err = diff.UniPatch([]rune("abcd"), []UniHunk[rune]{})
+	var epe *EmptyPatchError
+	if !errors.As(err, &epe) {
+		t.Fatalf("want: EmptyPatchError, got: %v", err)
+	}
+
+	_, err = diff.UniPatch([]rune("ab"), diff.UnifiedHunks())
+	if !errors.As(err, &oore) || oore.Hunk != 1 || oore.Pos != 3 {
+		t.Fatalf("want: HunkOutOfRangeError at 3 in hunk #1, got: %v", err)
+	}
+
+	uniHunks := diff.UnifiedHunks()
+	_, err = diff.UniPatch([]rune("abcd"), append(uniHunks, uniHunks...))
+	var hoe *HunkOverlapError
+	if !errors.As(err, &hoe) {
+		t.Fatalf("want: HunkOverlapError, got: %v", err)
+	}
+	if expected := (HunkOverlapError{Hunk: 2, Pos: 1, Prev: 5}); *hoe != expected {
+		t.Fatalf("want: %+v, got: %+v", expected, *hoe)
+	}
+}
+
 func BenchmarkPatchSmall(b *testing.B) {
 	s1 := []rune("abc")
 	s2 := []rune("abd")
@@ -103,7 +148,7 @@ func BenchmarkPatchSmall(b *testing.B) {
 	diff.Compose()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = diff.Patch(s1)
+		_, _ = diff.Patch(s1)
 	}
 }
 
@@ -126,7 +171,7 @@ func BenchmarkPatchLarge(b *testing.B) {
 	diff.Compose()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = diff.Patch(s1)
+		_, _ = diff.Patch(s1)
 	}
 }
 
@@ -152,7 +197,10 @@ func FuzzPatch(f *testing.F) {
 		diff := New([]rune(a), []rune(b))
 		diff.Compose()
 
-		patchedSeq := diff.Patch([]rune(a))
+		patchedSeq, err := diff.Patch([]rune(a))
+		if err != nil {
+			t.Fatalf("applying SES between '%s' and '%s': unexpected error: %v", a, b, err)
+		}
 		if string(patchedSeq) != b {
 			t.Errorf("applying SES between '%s' and '%s' to '%s' is %s, but got %s", a, b, a, b, string(patchedSeq))
 		}
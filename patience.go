@@ -0,0 +1,142 @@
+package gonp
+
+import (
+	"slices"
+	"sort"
+)
+
+// composePatience composes diff between a and b with AlgorithmPatience.
+// Elements occurring exactly once in both a and b become anchors
+// if they are in the longest common subsequence of such elements,
+// and parts between anchors are composed recursively, or with AlgorithmONP when they have no anchors.
+func (diff *Diff[T]) composePatience() {
+	stack := []editBox{{x1: diff.aLen, y1: diff.bLen}}
+	for len(stack) > 0 {
+		box := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if box.common {
+			diff.appendDiagonal(box)
+			continue
+		}
+		box, stack = diff.trimBox(box, stack)
+
+		anchors := diff.patienceAnchors(box)
+		if len(anchors) == 0 {
+			diff.composeBox(box)
+			continue
+		}
+
+		// push parts in reverse order so that they are popped from the beginning
+		stack = append(stack, editBox{x0: anchors[len(anchors)-1].x + 1, x1: box.x1, y0: anchors[len(anchors)-1].y + 1, y1: box.y1})
+		for i := len(anchors) - 1; i >= 0; i-- {
+			x0, y0 := box.x0, box.y0
+			if i > 0 {
+				x0, y0 = anchors[i-1].x+1, anchors[i-1].y+1
+			}
+			stack = append(stack,
+				editBox{x0: anchors[i].x, x1: anchors[i].x + 1, y0: anchors[i].y, y1: anchors[i].y + 1, common: true},
+				editBox{x0: x0, x1: anchors[i].x, y0: y0, y1: anchors[i].y},
+			)
+		}
+	}
+}
+
+// patienceAnchors returns points of elements occurring exactly once in both a and b of box,
+// which are in the longest sequence increasing in both a and b
+func (diff *Diff[T]) patienceAnchors(box editBox) []Point {
+	// sort positions of elements in a and b, so that equal elements are adjacent
+	type occurrence struct {
+		idx int
+		inB bool
+	}
+	occurrences := make([]occurrence, 0, box.x1-box.x0+box.y1-box.y0)
+	for x := box.x0; x < box.x1; x++ {
+		occurrences = append(occurrences, occurrence{idx: x})
+	}
+	for y := box.y0; y < box.y1; y++ {
+		occurrences = append(occurrences, occurrence{idx: y, inB: true})
+	}
+	elem := func(o occurrence) T {
+		if o.inB {
+			return diff.b[o.idx]
+		}
+		return diff.a[o.idx]
+	}
+	slices.SortStableFunc(occurrences, func(p, q occurrence) int { return diff.cmp(elem(p), elem(q)) })
+
+	unique := make([]Point, 0)
+	for i := 0; i < len(occurrences); {
+		j := i + 1
+		for j < len(occurrences) && diff.cmp(elem(occurrences[i]), elem(occurrences[j])) == 0 {
+			j++
+		}
+		// a precedes b in a run of equal elements since the sort is stable
+		if j-i == 2 && !occurrences[i].inB && occurrences[i+1].inB {
+			unique = append(unique, Point{x: occurrences[i].idx, y: occurrences[i+1].idx})
+		}
+		i = j
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i].x < unique[j].x })
+
+	return longestIncreasing(unique)
+}
+
+// longestIncreasing returns the longest subsequence of points sorted by x whose y is increasing,
+// with patience sorting
+func longestIncreasing(points []Point) []Point {
+	tops := make([]int, 0)           // index of the point on the top of each pile
+	prev := make([]int, len(points)) // index of the point on the top of the previous pile when pushed
+	for i, p := range points {
+		pile := sort.Search(len(tops), func(j int) bool { return points[tops[j]].y > p.y })
+		prev[i] = -1
+		if pile > 0 {
+			prev[i] = tops[pile-1]
+		}
+		if pile == len(tops) {
+			tops = append(tops, i)
+		} else {
+			tops[pile] = i
+		}
+	}
+
+	lis := make([]Point, len(tops))
+	for i, k := len(tops)-1, -1; i >= 0; i-- {
+		if k == -1 {
+			k = tops[i]
+		} else {
+			k = prev[k]
+		}
+		lis[i] = points[k]
+	}
+	return lis
+}
+
+// composeBox composes diff in box with AlgorithmONP
+func (diff *Diff[T]) composeBox(box editBox) {
+	n, m := box.x1-box.x0, box.y1-box.y0
+	if n == 0 || m == 0 || (n == 1 && m == 1 && diff.cmp(diff.a[box.x0], diff.b[box.y0]) != 0) {
+		diff.appendChanges(box)
+		return
+	}
+
+	a, b := diff.a[box.x0:box.x1], diff.b[box.y0:box.y1]
+	if diff.reverse {
+		// compose in the same direction as a whole
+		a, b = b, a
+	}
+	sub := NewCmp(a, b, diff.cmp).SetRouteSize(diff.routeSize)
+	sub.Compose()
+	diff.appendSes(box, sub.ses)
+}
+
+// appendSes records SES in box between a and b given to New
+func (diff *Diff[T]) appendSes(box editBox, ses []SesElem[T]) {
+	ox, oy := box.x0, box.y0
+	if diff.reverse {
+		ox, oy = box.y0, box.x0
+	}
+	for _, e := range ses {
+		diff.appendEdit(e.typ, ox+e.aIdx-1, oy+e.bIdx-1)
+	}
+}
@@ -0,0 +1,168 @@
+package gonp
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffAlgorithmPatience(t *testing.T) {
+	// example of patience diff by Bram Cohen
+	a := strings.Split(`#include <stdio.h>
+
+// Frobs foo heartily
+int frobnitz(int foo)
+{
+    int i;
+    for(i = 0; i < 10; i++)
+    {
+        printf("Your answer is: ");
+        printf("%d\n", foo);
+    }
+}
+
+int fact(int n)
+{
+    if(n > 1)
+    {
+        return fact(n-1) * n;
+    }
+    return 1;
+}
+
+int main(int argc, char **argv)
+{
+    frobnitz(fact(10));
+}`, "\n")
+	b := strings.Split(`#include <stdio.h>
+
+int fib(int n)
+{
+    if(n > 2)
+    {
+        return fib(n-1) + fib(n-2);
+    }
+    return 1;
+}
+
+// Frobs foo heartily
+int frobnitz(int foo)
+{
+    int i;
+    for(i = 0; i < 10; i++)
+    {
+        printf("%d\n", foo);
+    }
+}
+
+int main(int argc, char **argv)
+{
+    frobnitz(fib(10));
+}`, "\n")
+
+	diff := New(a, b).SetAlgorithm(AlgorithmPatience)
+	diff.Compose()
+	checkSes(t, a, b, diff.Ses())
+
+	expected := `@@ -1,5 +1,14 @@
+ #include <stdio.h>
+ 
++int fib(int n)
++{
++    if(n > 2)
++    {
++        return fib(n-1) + fib(n-2);
++    }
++    return 1;
++}
++
+ // Frobs foo heartily
+ int frobnitz(int foo)
+ {
+@@ -6,7 +15,6 @@
+     int i;
+     for(i = 0; i < 10; i++)
+     {
+-        printf("Your answer is: ");
+         printf("%d\n", foo);
+     }
+ }
+@@ -13,14 +21,5 @@
+ 
+-int fact(int n)
+-{
+-    if(n > 1)
+-    {
+-        return fact(n-1) * n;
+-    }
+-    return 1;
+-}
+-
+ int main(int argc, char **argv)
+ {
+-    frobnitz(fact(10));
++    frobnitz(fib(10));
+ }
+`
+	if actual := SprintUniHunks(diff.UnifiedHunks()); actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestPatienceAnchors(t *testing.T) {
+	a := []string{"f", "}", "g", "}", "h", "}", "k"}
+	b := []string{"g", "}", "h", "}", "f", "}", "k", "x"}
+	diff := New(a, b)
+
+	// "}" is not unique and "f" moving behind "g" and "h" is not an anchor
+	expected := []Point{{x: 2, y: 0}, {x: 4, y: 2}, {x: 6, y: 6}}
+	if actual := diff.patienceAnchors(editBox{x1: len(a), y1: len(b)}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestLongestIncreasing(t *testing.T) {
+	points := []Point{{0, 9}, {1, 4}, {2, 6}, {3, 2}, {4, 8}, {5, 7}, {6, 1}, {7, 10}}
+	expected := []Point{{1, 4}, {2, 6}, {5, 7}, {7, 10}}
+	if actual := longestIncreasing(points); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+	if actual := longestIncreasing([]Point{}); len(actual) != 0 {
+		t.Fatalf("want: %v, actual: %v", []Point{}, actual)
+	}
+}
+
+func TestDiffAlgorithmPatienceRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func() []int {
+		s := make([]int, rnd.Intn(60))
+		for i := range s {
+			s[i] = rnd.Intn(20)
+		}
+		return s
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		diff := New(a, b).SetAlgorithm(AlgorithmPatience)
+		diff.Compose()
+		checkSes(t, a, b, diff.Ses())
+
+		onlyEd := New(a, b).SetAlgorithm(AlgorithmPatience).OnlyEd()
+		onlyEd.Compose()
+		if onlyEd.EditDistance() != diff.EditDistance() {
+			t.Fatalf(":%v,%v: want: %v, actual: %v", a, b, diff.EditDistance(), onlyEd.EditDistance())
+		}
+	}
+}
+
+func BenchmarkStringDiffComposePatience(b *testing.B) {
+	s1 := []rune("abc")
+	s2 := []rune("abd")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		diff := New(s1, s2).SetAlgorithm(AlgorithmPatience)
+		diff.Compose()
+	}
+}
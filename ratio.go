@@ -0,0 +1,88 @@
+package gonp
+
+import (
+	"slices"
+	"sort"
+)
+
+// ratio returns 2*M/T, 1 if T is 0
+func ratio(m, t int) float64 {
+	if t == 0 {
+		return 1.0
+	}
+	return 2.0 * float64(m) / float64(t)
+}
+
+// Ratio returns similarity between a and b in [0, 1] like SequenceMatcher.ratio() of Python difflib.
+// It is 2*M/T, where M is the length of LCS and T is the total number of elements of a and b.
+// It works with OnlyEd as well and must be called after Compose.
+func (diff *Diff[T]) Ratio() float64 {
+	t := len(diff.seqA) + len(diff.seqB)
+	if !diff.onlyEd {
+		return ratio(len(diff.lcs), t)
+	}
+	return ratio((t-diff.ed)/2, t)
+}
+
+// QuickRatio returns an upper bound on Ratio without Compose.
+// M is the number of elements a and b have in common regardless of their order.
+func (diff *Diff[T]) QuickRatio() float64 {
+	a, b := slices.Clone(diff.seqA), slices.Clone(diff.seqB)
+	slices.SortFunc(a, diff.cmp)
+	slices.SortFunc(b, diff.cmp)
+
+	m := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch c := diff.cmp(a[i], b[j]); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			m++
+			i++
+			j++
+		}
+	}
+	return ratio(m, len(a)+len(b))
+}
+
+// RealQuickRatio returns an upper bound on QuickRatio from the lengths of a and b only
+func (diff *Diff[T]) RealQuickRatio() float64 {
+	m, n := len(diff.seqA), len(diff.seqB)
+	return ratio(min(m, n), m+n)
+}
+
+// CloseMatches returns at most n candidates whose Ratio to word is at least cutoff,
+// best matches first like get_close_matches() of Python difflib.
+// Candidates with the same ratio keep their order.
+func CloseMatches(word string, candidates []string, n int, cutoff float64) []string {
+	if n <= 0 {
+		return []string{}
+	}
+
+	type match struct {
+		candidate string
+		ratio     float64
+	}
+
+	w := []rune(word)
+	matches := make([]match, 0)
+	for _, candidate := range candidates {
+		diff := New(w, []rune(candidate)).OnlyEd()
+		if diff.RealQuickRatio() < cutoff || diff.QuickRatio() < cutoff {
+			continue
+		}
+		diff.Compose()
+		if r := diff.Ratio(); r >= cutoff {
+			matches = append(matches, match{candidate: candidate, ratio: r})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].ratio > matches[j].ratio })
+	result := make([]string, 0, min(n, len(matches)))
+	for i := 0; i < len(matches) && i < n; i++ {
+		result = append(result, matches[i].candidate)
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package gonp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRatio(t *testing.T) {
+	tests := []struct {
+		a, b                              string
+		ratio, quickRatio, realQuickRatio float64
+	}{
+		{a: "abcd", b: "bcde", ratio: 0.75, quickRatio: 0.75, realQuickRatio: 1.0},
+		{a: "abc", b: "cba", ratio: 1.0 / 3, quickRatio: 1.0, realQuickRatio: 1.0},
+		{a: "abc", b: "abc", ratio: 1.0, quickRatio: 1.0, realQuickRatio: 1.0},
+		{a: "ab", b: "xyzw", ratio: 0, quickRatio: 0, realQuickRatio: 2.0 / 3},
+		{a: "", b: "", ratio: 1.0, quickRatio: 1.0, realQuickRatio: 1.0},
+	}
+
+	for _, tt := range tests {
+		diff := New([]rune(tt.a), []rune(tt.b))
+		if actual := diff.QuickRatio(); actual != tt.quickRatio {
+			t.Fatalf(":%s,%s: want: %v, actual: %v", tt.a, tt.b, tt.quickRatio, actual)
+		}
+		if actual := diff.RealQuickRatio(); actual != tt.realQuickRatio {
+			t.Fatalf(":%s,%s: want: %v, actual: %v", tt.a, tt.b, tt.realQuickRatio, actual)
+		}
+		diff.Compose()
+		if actual := diff.Ratio(); actual != tt.ratio {
+			t.Fatalf(":%s,%s: want: %v, actual: %v", tt.a, tt.b, tt.ratio, actual)
+		}
+
+		diff = New([]rune(tt.a), []rune(tt.b)).OnlyEd()
+		diff.Compose()
+		if actual := diff.Ratio(); actual != tt.ratio {
+			t.Fatalf(":%s,%s: only ed: want: %v, actual: %v", tt.a, tt.b, tt.ratio, actual)
+		}
+	}
+}
+
+func TestDiffRatioInt(t *testing.T) {
+	diff := New([]int{1, 2, 3, 4, 5}, []int{1, 3, 4, 5, 6, 7})
+	diff.Compose()
+	if actual, expected := diff.Ratio(), 8.0/11; actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestDiffRatioRouteSize(t *testing.T) {
+	// pseudo random sequences make Compose start over from the middle with a small route size
+	a, b := make([]int, 300), make([]int, 300)
+	seed := 1
+	for i := range a {
+		seed = (seed*1103515245 + 12345) % (1 << 31)
+		a[i] = seed % 10
+		seed = (seed*1103515245 + 12345) % (1 << 31)
+		b[i] = seed % 10
+	}
+
+	diff := New(a, b).SetRouteSize(5)
+	quickRatio, realQuickRatio := diff.QuickRatio(), diff.RealQuickRatio()
+	diff.Compose()
+	if actual, expected := diff.Ratio(), 2.0*float64(len(diff.Lcs()))/600; actual != expected {
+		t.Fatalf("want: %v, actual: %v", expected, actual)
+	}
+	if actual := diff.QuickRatio(); actual != quickRatio {
+		t.Fatalf("quick ratio: want: %v, actual: %v", quickRatio, actual)
+	}
+	if actual := diff.RealQuickRatio(); actual != realQuickRatio {
+		t.Fatalf("real quick ratio: want: %v, actual: %v", realQuickRatio, actual)
+	}
+}
+
+func TestCloseMatches(t *testing.T) {
+	tests := []struct {
+		name       string
+		word       string
+		candidates []string
+		n          int
+		cutoff     float64
+		expected   []string
+	}{
+		{
+			// example of Python difflib
+			name:       "appel",
+			word:       "appel",
+			candidates: []string{"ape", "apple", "peach", "puppy"},
+			n:          3,
+			cutoff:     0.6,
+			expected:   []string{"apple", "ape"},
+		},
+		{
+			name:       "n",
+			word:       "appel",
+			candidates: []string{"ape", "apple", "peach", "puppy"},
+			n:          1,
+			cutoff:     0.6,
+			expected:   []string{"apple"},
+		},
+		{
+			name:       "cutoff",
+			word:       "wheel",
+			candidates: []string{"while", "with", "when"},
+			n:          3,
+			cutoff:     0.9,
+			expected:   []string{},
+		},
+		{
+			name:       "tie",
+			word:       "abcd",
+			candidates: []string{"abxd", "abce", "abcdef"},
+			n:          3,
+			cutoff:     0.6,
+			expected:   []string{"abcdef", "abxd", "abce"},
+		},
+		{
+			name:       "zero",
+			word:       "appel",
+			candidates: []string{"apple"},
+			n:          0,
+			cutoff:     0.6,
+			expected:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		if actual := CloseMatches(tt.word, tt.candidates, tt.n, tt.cutoff); !reflect.DeepEqual(actual, tt.expected) {
+			t.Fatalf(":%s: want: %v, actual: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package gonp
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// RuneRange is a range [Start, End) of runes in a line
+type RuneRange struct {
+	Start, End int
+}
+
+// LineRefinement is intra-line difference between a pair of deleted and added lines
+type LineRefinement struct {
+	OldIdx, NewIdx int               // 1-origin index of the deleted line in a and the added line in b
+	Ses            []SesElem[string] // SES between tokens of the lines
+	Old, New       []RuneRange       // changed runes of the deleted line and the added line
+}
+
+// RefineOptions configures intra-line refinement
+type RefineOptions[T any] struct {
+	// Format formats an element, fmt.Sprint if nil
+	Format func(T) string
+	// Split splits a line into tokens, into runes if nil. e.g. SplitWords
+	Split func(string) []string
+}
+
+func (opts *RefineOptions[T]) format(e T) string {
+	if opts.Format == nil {
+		return fmt.Sprint(e)
+	}
+	return opts.Format(e)
+}
+
+func (opts *RefineOptions[T]) split(s string) []string {
+	if opts.Split != nil {
+		return opts.Split(s)
+	}
+	tokens := make([]string, 0, len(s))
+	for _, r := range s {
+		tokens = append(tokens, string(r))
+	}
+	return tokens
+}
+
+// Refinements pairs the i-th deleted line with the i-th added line of each run of changes in uniHunk,
+// and returns difference between tokens of each pair
+func (uniHunk *UniHunk[T]) Refinements(opts RefineOptions[T]) []LineRefinement {
+	refinements := make([]LineRefinement, 0)
+	for _, row := range sideBySideRows(uniHunk.changes) {
+		if row.marker != '|' {
+			continue
+		}
+
+		diff := New(opts.split(opts.format(row.left.elem)), opts.split(opts.format(row.right.elem)))
+		diff.Compose()
+		older, newer := changedRanges(diff.ses)
+		refinements = append(refinements, LineRefinement{
+			OldIdx: row.left.aIdx,
+			NewIdx: row.right.bIdx,
+			Ses:    diff.ses,
+			Old:    older,
+			New:    newer,
+		})
+	}
+	return refinements
+}
+
+// changedRanges returns ranges of runes deleted from a and added to b by SES of tokens
+func changedRanges(ses []SesElem[string]) (older, newer []RuneRange) {
+	older, newer = make([]RuneRange, 0), make([]RuneRange, 0)
+	extend := func(ranges []RuneRange, pos, n int) []RuneRange {
+		if l := len(ranges); l > 0 && ranges[l-1].End == pos {
+			ranges[l-1].End += n
+			return ranges
+		}
+		return append(ranges, RuneRange{Start: pos, End: pos + n})
+	}
+
+	x, y := 0, 0
+	for _, e := range ses {
+		n := utf8.RuneCountInString(e.elem)
+		switch e.typ {
+		case SesDelete:
+			older = extend(older, x, n)
+			x += n
+		case SesAdd:
+			newer = extend(newer, y, n)
+			y += n
+		case SesCommon:
+			x += n
+			y += n
+		}
+	}
+	return older, newer
+}
+
+// SprintRanges returns s with runes in ranges wrapped by prefix and suffix
+func SprintRanges(s string, ranges []RuneRange, prefix, suffix string) string {
+	var sb strings.Builder
+	rs := []rune(s)
+	pos := 0
+	for _, r := range ranges {
+		sb.WriteString(string(rs[pos:r.Start]))
+		sb.WriteString(prefix + string(rs[r.Start:r.End]) + suffix)
+		pos = r.End
+	}
+	sb.WriteString(string(rs[pos:]))
+	return sb.String()
+}
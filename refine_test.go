@@ -0,0 +1,60 @@
+package gonp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUniHunkRefinements(t *testing.T) {
+	a := []string{"a", "foo bar", "baz qux", "c"}
+	b := []string{"a", "foo Bar", "c", "d"}
+	diff := New(a, b)
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	refinements := uniHunks[0].Refinements(RefineOptions[string]{})
+	if len(refinements) != 1 {
+		t.Fatalf("want: %v, actual: %v", 1, len(refinements))
+	}
+	r := refinements[0]
+	if r.OldIdx != 2 || r.NewIdx != 2 {
+		t.Fatalf("want: %v, actual: %v", "2 2", []int{r.OldIdx, r.NewIdx})
+	}
+	if expected := []RuneRange{{Start: 4, End: 5}}; !reflect.DeepEqual(r.Old, expected) {
+		t.Fatalf("want: %v, actual: %v", expected, r.Old)
+	}
+	if expected := []RuneRange{{Start: 4, End: 5}}; !reflect.DeepEqual(r.New, expected) {
+		t.Fatalf("want: %v, actual: %v", expected, r.New)
+	}
+	if len(r.Ses) != 8 {
+		t.Fatalf("want: %v, actual: %v", 8, len(r.Ses))
+	}
+}
+
+func TestUniHunkRefinementsWords(t *testing.T) {
+	diff := New([]string{"日本 の 首都"}, []string{"日本 と 首都 です"})
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	r := uniHunks[0].Refinements(RefineOptions[string]{Split: SplitWords})[0]
+	if expected := []RuneRange{{Start: 3, End: 4}}; !reflect.DeepEqual(r.Old, expected) {
+		t.Fatalf("want: %v, actual: %v", expected, r.Old)
+	}
+	if expected := []RuneRange{{Start: 3, End: 4}, {Start: 7, End: 10}}; !reflect.DeepEqual(r.New, expected) {
+		t.Fatalf("want: %v, actual: %v", expected, r.New)
+	}
+	if actual := SprintRanges("日本 と 首都 です", r.New, "[", "]"); actual != "日本 [と] 首都[ です]" {
+		t.Fatalf("want: %v, actual: %v", "日本 [と] 首都[ です]", actual)
+	}
+}
+
+func TestUniHunkRefinementsFormat(t *testing.T) {
+	diff := New([]int{10, 20}, []int{10, 21})
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	r := uniHunks[0].Refinements(RefineOptions[int]{})[0]
+	if expected := []RuneRange{{Start: 1, End: 2}}; !reflect.DeepEqual(r.Old, expected) || !reflect.DeepEqual(r.New, expected) {
+		t.Fatalf("want: %v, actual: %v %v", expected, r.Old, r.New)
+	}
+}
@@ -0,0 +1,143 @@
+package gonp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// DefaultSideBySideWidth is the default total width of side-by-side format difference
+	DefaultSideBySideWidth = 130
+	// DefaultTabSize is the default width tabs are expanded to
+	DefaultTabSize = 8
+)
+
+// SideBySideOptions configures side-by-side format difference
+type SideBySideOptions[T any] struct {
+	// Width is the total width of a line, DefaultSideBySideWidth if 0
+	Width int
+	// TabSize is the width tabs are expanded to, DefaultTabSize if 0
+	TabSize int
+	// SuppressCommon omits common elements
+	SuppressCommon bool
+	// Format formats an element, fmt.Sprint if nil
+	Format func(T) string
+}
+
+// sideBySideRow is a row of side-by-side format difference
+type sideBySideRow[T any] struct {
+	left, right *SesElem[T]
+	marker      byte
+}
+
+// sideBySideRows pairs deleted and added elements of each run of changes
+func sideBySideRows[T any](ses []SesElem[T]) []sideBySideRow[T] {
+	rows := make([]sideBySideRow[T], 0, len(ses))
+	for i := 0; i < len(ses); {
+		if ses[i].typ == SesCommon {
+			rows = append(rows, sideBySideRow[T]{left: &ses[i], right: &ses[i], marker: ' '})
+			i++
+			continue
+		}
+
+		deleted, added := make([]*SesElem[T], 0), make([]*SesElem[T], 0)
+		for ; i < len(ses) && ses[i].typ != SesCommon; i++ {
+			if ses[i].typ == SesDelete {
+				deleted = append(deleted, &ses[i])
+			} else {
+				added = append(added, &ses[i])
+			}
+		}
+		for j := 0; j < max(len(deleted), len(added)); j++ {
+			switch {
+			case j >= len(added):
+				rows = append(rows, sideBySideRow[T]{left: deleted[j], marker: '<'})
+			case j >= len(deleted):
+				rows = append(rows, sideBySideRow[T]{right: added[j], marker: '>'})
+			default:
+				rows = append(rows, sideBySideRow[T]{left: deleted[j], right: added[j], marker: '|'})
+			}
+		}
+	}
+	return rows
+}
+
+// PrintSideBySide prints side-by-side format difference between a and b
+func (diff *Diff[T]) PrintSideBySide(opts SideBySideOptions[T]) {
+	fmt.Print(diff.SprintSideBySide(opts))
+}
+
+// SprintSideBySide returns string about side-by-side format difference between a and b
+func (diff *Diff[T]) SprintSideBySide(opts SideBySideOptions[T]) string {
+	var buf bytes.Buffer
+	diff.FprintSideBySide(&buf, opts)
+	return buf.String()
+}
+
+// FprintSideBySide emit about side-by-side format difference between a and b to w like "diff -y".
+// Elements of a are written in the left column and ones of b in the right column,
+// separated by "|" for changed, "<" for deleted and ">" for added elements.
+func (diff *Diff[T]) FprintSideBySide(w io.Writer, opts SideBySideOptions[T]) {
+	width := opts.Width
+	if width <= 0 {
+		width = DefaultSideBySideWidth
+	}
+	tabSize := opts.TabSize
+	if tabSize <= 0 {
+		tabSize = DefaultTabSize
+	}
+	format := opts.Format
+	if format == nil {
+		format = func(e T) string { return fmt.Sprint(e) }
+	}
+
+	column := max((width-3)/2, 1)
+	cell := func(e *SesElem[T]) string {
+		if e == nil {
+			return ""
+		}
+		return truncate(expandTabs(format(e.elem), tabSize), column)
+	}
+
+	for _, row := range sideBySideRows(diff.ses) {
+		if row.marker == ' ' && opts.SuppressCommon {
+			continue
+		}
+
+		left := cell(row.left)
+		line := left + strings.Repeat(" ", column-len([]rune(left))) + " " + string(row.marker) + " " + cell(row.right)
+		fmt.Fprintf(w, "%s\n", strings.TrimRight(line, " "))
+	}
+}
+
+// expandTabs replaces tabs in s with spaces up to the next tab stop
+func expandTabs(s string, tabSize int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+
+	var sb strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			n := tabSize - col%tabSize
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		sb.WriteRune(r)
+		col++
+	}
+	return sb.String()
+}
+
+// truncate cuts s to n runes
+func truncate(s string, n int) string {
+	rs := []rune(s)
+	if len(rs) <= n {
+		return s
+	}
+	return string(rs[:n])
+}
@@ -0,0 +1,66 @@
+package gonp
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDiffSprintSideBySide(t *testing.T) {
+	a := []string{"a", "b", "c", "d", "e"}
+	b := []string{"a", "B", "c", "e", "f", "g"}
+	diff := New(a, b)
+	diff.Compose()
+
+	tests := []struct {
+		name     string
+		opts     SideBySideOptions[string]
+		expected string
+	}{
+		{
+			name: "narrow",
+			opts: SideBySideOptions[string]{Width: 11},
+			expected: `a      a
+b    | B
+c      c
+d    <
+e      e
+     > f
+     > g
+`,
+		},
+		{
+			name: "suppress common",
+			opts: SideBySideOptions[string]{Width: 11, SuppressCommon: true},
+			expected: `b    | B
+d    <
+     > f
+     > g
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		if actual := diff.SprintSideBySide(tt.opts); actual != tt.expected {
+			t.Fatalf(":%s: want: %q, actual: %q", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestDiffSprintSideBySideFormat(t *testing.T) {
+	a := []int{1, 22, 333}
+	b := []int{1, 4444, 333}
+	diff := New(a, b)
+	diff.Compose()
+
+	actual := diff.SprintSideBySide(SideBySideOptions[int]{
+		Width:   13,
+		TabSize: 2,
+		Format:  func(n int) string { return "\t" + strconv.Itoa(n) },
+	})
+	expected := "  1       1\n" +
+		"  22  |   444\n" +
+		"  333     333\n"
+	if actual != expected {
+		t.Fatalf("want: %q, actual: %q", expected, actual)
+	}
+}
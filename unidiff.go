@@ -18,8 +18,13 @@ const (
 
 // UniHunk is an element of unified format difference
 type UniHunk[T Elem] struct {
-	a, b, c, d int // @@ -a,b +c,d @@
+	a, b, c, d int    // @@ -a,b +c,d @@
+	section    string // optional text following the range
 	changes    []SesElem[T]
+
+	// original text of a parsed hunk, so that it is written back as is
+	header string         // range line
+	notes  map[int]string // "\ No newline at end of file" following changes[i]
 }
 
 // GetChanges is getter of changes in UniHunk
@@ -27,8 +32,73 @@ func (uniHunk *UniHunk[T]) GetChanges() []SesElem[T] {
 	return uniHunk.changes
 }
 
+// HunkRange is a range of hunk, "@@ -OldStart,OldLines +NewStart,NewLines @@" in unified format.
+// When the number of lines is 0, the start is the line before the hunk.
+type HunkRange struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+}
+
+// GetRange is getter of range of UniHunk
+func (uniHunk *UniHunk[T]) GetRange() HunkRange {
+	return HunkRange{OldStart: uniHunk.a, OldLines: uniHunk.b, NewStart: uniHunk.c, NewLines: uniHunk.d}
+}
+
+// NewUniHunk returns UniHunk in r consisting of changes.
+// It fails if the numbers of lines in r do not match changes,
+// or an added change has an index in a or a deleted change has an index in b.
+func NewUniHunk[T any](r HunkRange, changes []SesElem[T]) (UniHunk[T], error) {
+	uniHunk := UniHunk[T]{a: r.OldStart, b: r.OldLines, c: r.NewStart, d: r.NewLines, changes: changes}
+	if err := uniHunk.checkRange(); err != nil {
+		return UniHunk[T]{}, err
+	}
+	return uniHunk, nil
+}
+
+// checkRange verifies that the numbers of lines in the range match changes with valid indices
+func (uniHunk *UniHunk[T]) checkRange() error {
+	if uniHunk.a < 0 || uniHunk.c < 0 {
+		return fmt.Errorf("hunk @@ -%d,%d +%d,%d @@ has negative start", uniHunk.a, uniHunk.b, uniHunk.c, uniHunk.d)
+	}
+	b, d := 0, 0
+	for i, e := range uniHunk.changes {
+		if err := e.checkIdx(); err != nil {
+			return fmt.Errorf("change #%d of hunk: %w", i, err)
+		}
+		if e.typ != SesAdd {
+			b++
+		}
+		if e.typ != SesDelete {
+			d++
+		}
+	}
+	if b != uniHunk.b || d != uniHunk.d {
+		return fmt.Errorf("hunk @@ -%d,%d +%d,%d @@ has %d and %d elements", uniHunk.a, uniHunk.b, uniHunk.c, uniHunk.d, b, d)
+	}
+	return nil
+}
+
+// checkIdx verifies that indices of e are not negative and e is not in a or b if its type says so
+func (e *SesElem[T]) checkIdx() error {
+	switch {
+	case e.aIdx < 0 || e.bIdx < 0:
+		return fmt.Errorf("negative index of SES element")
+	case e.typ == SesAdd && e.aIdx != 0:
+		return fmt.Errorf("added SES element has index %d in a", e.aIdx)
+	case e.typ == SesDelete && e.bIdx != 0:
+		return fmt.Errorf("deleted SES element has index %d in b", e.bIdx)
+	}
+	return nil
+}
+
 // SprintDiffRange returns formatted string represents difference range
 func (uniHunk *UniHunk[T]) SprintDiffRange() string {
+	if uniHunk.header != "" {
+		return uniHunk.header + "\n"
+	}
+	if uniHunk.section != "" {
+		return fmt.Sprintf("@@ -%d,%d +%d,%d @@ %s\n", uniHunk.a, uniHunk.b, uniHunk.c, uniHunk.d, uniHunk.section)
+	}
 	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", uniHunk.a, uniHunk.b, uniHunk.c, uniHunk.d)
 }
 
@@ -46,16 +116,16 @@ func SprintUniHunks[T any](uniHunks []UniHunk[T]) string {
 
 // FprintUniHunks emit about unified format difference between a and b to w
 func FprintUniHunks[T any](w io.Writer, uniHunks []UniHunk[T]) {
+	fprintUniHunks(w, uniHunks, nil)
+}
+
+func fprintUniHunks[T any](w io.Writer, uniHunks []UniHunk[T], p *Palette) {
 	for _, uniHunk := range uniHunks {
-		fmt.Fprintf(w, uniHunk.SprintDiffRange())
-		for _, e := range uniHunk.GetChanges() {
-			switch e.GetType() {
-			case SesDelete:
-				fmt.Fprintf(w, "-%v\n", e.GetElem())
-			case SesAdd:
-				fmt.Fprintf(w, "+%v\n", e.GetElem())
-			case SesCommon:
-				fmt.Fprintf(w, " %v\n", e.GetElem())
+		fmt.Fprint(w, sprintHunkRange(&uniHunk, p))
+		for i, e := range uniHunk.GetChanges() {
+			fmt.Fprint(w, sprintSesElem(e, p))
+			if note, ok := uniHunk.notes[i]; ok {
+				fmt.Fprintf(w, "%s\n", note)
 			}
 		}
 	}
@@ -63,6 +133,11 @@ func FprintUniHunks[T any](w io.Writer, uniHunks []UniHunk[T]) {
 
 // UnifiedHunks composes unified format difference between a and b
 func (diff *Diff[T]) UnifiedHunks() []UniHunk[T] {
+	return diff.unifiedHunks(diff.contextSize)
+}
+
+// unifiedHunks composes unified format difference with contextSize elements of context
+func (diff *Diff[T]) unifiedHunks(contextSize int) []UniHunk[T] {
 	if diff.ed == 0 {
 		return []UniHunk[T]{}
 	}
@@ -71,6 +146,7 @@ func (diff *Diff[T]) UnifiedHunks() []UniHunk[T] {
 	phase := PhaseFrontDiff
 	cc := 0
 	b, d := 0, 0
+	x, y := 0, 0 // numbers of elements of a and b before e
 
 	for i, e := range diff.ses {
 		switch e.typ {
@@ -95,38 +171,52 @@ func (diff *Diff[T]) UnifiedHunks() []UniHunk[T] {
 			switch phase {
 			case PhaseFrontDiff:
 				changes = append(changes, e)
-				if len(changes) > diff.contextSize {
+				if len(changes) > contextSize {
 					changes = changes[1:]
 					b -= 1
 					d -= 1
 				}
+				b += 1
+				d += 1
 			case PhaseInDiff:
+				if contextSize == 0 {
+					// e is not a part of hunk without context
+					phase = PhaseBehindDiff
+					break
+				}
 				changes = append(changes, e)
 				cc += 1
-				if cc == diff.contextSize {
+				if cc == contextSize {
 					phase = PhaseBehindDiff
 				}
+				b += 1
+				d += 1
 			case PhaseBehindDiff:
 				// do nothing
 			}
-			b += 1
-			d += 1
 		}
 
-		if phase == PhaseBehindDiff || i == len(diff.ses)-1 {
+		if phase == PhaseBehindDiff || (phase == PhaseInDiff && i == len(diff.ses)-1) {
 			a, c := 0, 0
-			for _, e = range changes {
+			for _, change := range changes {
 				if a == 0 {
-					a = e.aIdx
+					a = change.aIdx
 				}
 				if c == 0 {
-					c = e.bIdx
+					c = change.bIdx
 				}
 
 				if a != 0 && c != 0 {
 					break
 				}
 			}
+			// an empty range starts at the element before hunk
+			if b == 0 {
+				a = x
+			}
+			if d == 0 {
+				c = y
+			}
 
 			uniHunk := UniHunk[T]{
 				a: a, b: b, c: c, d: d,
@@ -140,6 +230,13 @@ func (diff *Diff[T]) UnifiedHunks() []UniHunk[T] {
 			changes = make([]SesElem[T], 0)
 			phase = PhaseFrontDiff
 		}
+
+		if e.typ != SesAdd {
+			x++
+		}
+		if e.typ != SesDelete {
+			y++
+		}
 	}
 
 	return uniHunks
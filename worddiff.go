@@ -0,0 +1,145 @@
+package gonp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// WordDiffMode is a format of word difference
+type WordDiffMode int
+
+const (
+	// WordDiffPlain marks words like "[-old-]{+new+}"
+	WordDiffPlain WordDiffMode = iota
+	// WordDiffPorcelain writes a line per run of words prefixed with " ", "-" or "+",
+	// and "~" for newlines, like "git diff --word-diff=porcelain"
+	WordDiffPorcelain
+	// WordDiffColor colorizes deleted and added words without markers
+	WordDiffColor
+)
+
+// WordDiffOptions configures word difference
+type WordDiffOptions struct {
+	// Mode is a format of word difference, WordDiffPlain if 0
+	Mode WordDiffMode
+	// Palette is a set of colors for WordDiffColor, DefaultPalette if nil
+	Palette *Palette
+}
+
+// SplitWords splits s into words and runs of whitespaces.
+// A newline is always a token by itself.
+func SplitWords(s string) []string {
+	words := make([]string, 0)
+	start := 0
+	var prev rune
+	for i, r := range s {
+		if i > start && (r == '\n' || prev == '\n' || unicode.IsSpace(r) != unicode.IsSpace(prev)) {
+			words = append(words, s[start:i])
+			start = i
+		}
+		prev = r
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+// NewWordDiff returns Diff between words of a and b
+func NewWordDiff(a, b string) *Diff[string] {
+	return New(SplitWords(a), SplitWords(b))
+}
+
+// wordDiffHunk composes word difference between lines of a and b in uniHunk
+func wordDiffHunk(uniHunk *UniHunk[string]) *Diff[string] {
+	var a, b strings.Builder
+	for _, e := range uniHunk.changes {
+		if e.typ != SesAdd {
+			a.WriteString(e.elem + "\n")
+		}
+		if e.typ != SesDelete {
+			b.WriteString(e.elem + "\n")
+		}
+	}
+	diff := NewWordDiff(a.String(), b.String())
+	diff.Compose()
+	return diff
+}
+
+// PrintWordDiff prints word difference of line-level uniHunks
+func PrintWordDiff(uniHunks []UniHunk[string], opts WordDiffOptions) {
+	fmt.Print(SprintWordDiff(uniHunks, opts))
+}
+
+// SprintWordDiff returns string about word difference of line-level uniHunks
+func SprintWordDiff(uniHunks []UniHunk[string], opts WordDiffOptions) string {
+	var buf bytes.Buffer
+	FprintWordDiff(&buf, uniHunks, opts)
+	return buf.String()
+}
+
+// FprintWordDiff emit about word difference of line-level uniHunks to w like "git diff --word-diff".
+// Lines of each hunk are split into words with SplitWords and compared with Diff,
+// so that hunks made by UnifiedHunks or ParseUniHunks give the context.
+func FprintWordDiff(w io.Writer, uniHunks []UniHunk[string], opts WordDiffOptions) {
+	var p *Palette
+	if opts.Mode == WordDiffColor {
+		p = opts.Palette
+		if p == nil {
+			p = &DefaultPalette
+		}
+	}
+
+	for _, uniHunk := range uniHunks {
+		fmt.Fprint(w, sprintHunkRange(&uniHunk, p))
+		fprintWordSes(w, wordDiffHunk(&uniHunk).ses, opts.Mode, p)
+	}
+}
+
+// fprintWordSes emits SES of words to w, breaking markers at newlines
+func fprintWordSes(w io.Writer, ses []SesElem[string], mode WordDiffMode, p *Palette) {
+	for _, run := range elemRuns(ses) {
+		for i, seg := range strings.Split(strings.Join(run.elems, ""), "\n") {
+			if mode == WordDiffPorcelain {
+				if i > 0 {
+					fmt.Fprint(w, "~\n")
+				}
+				if seg != "" {
+					fmt.Fprintf(w, "%s%s\n", porcelainPrefix(run.typ), seg)
+				}
+				continue
+			}
+
+			if i > 0 {
+				fmt.Fprint(w, "\n")
+			}
+			if seg == "" {
+				continue
+			}
+			switch {
+			case mode == WordDiffColor:
+				fmt.Fprint(w, p.paint(p.sesColor(run.typ), seg))
+			case run.typ == SesDelete:
+				fmt.Fprintf(w, "[-%s-]", seg)
+			case run.typ == SesAdd:
+				fmt.Fprintf(w, "{+%s+}", seg)
+			default:
+				fmt.Fprint(w, seg)
+			}
+		}
+	}
+}
+
+func porcelainPrefix(typ SesType) string {
+	switch typ {
+	case SesDelete:
+		return "-"
+	case SesAdd:
+		return "+"
+	default:
+		return " "
+	}
+}
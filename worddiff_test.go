@@ -0,0 +1,91 @@
+package gonp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected []string
+	}{
+		{s: "", expected: []string{}},
+		{s: "foo", expected: []string{"foo"}},
+		{s: "foo  bar\tbaz", expected: []string{"foo", "  ", "bar", "\t", "baz"}},
+		{s: " a\n\nb \n", expected: []string{" ", "a", "\n", "\n", "b", " ", "\n"}},
+		{s: "日本 語", expected: []string{"日本", " ", "語"}},
+	}
+
+	for _, tt := range tests {
+		if actual := SplitWords(tt.s); !reflect.DeepEqual(actual, tt.expected) {
+			t.Fatalf(":%q: want: %q, actual: %q", tt.s, tt.expected, actual)
+		}
+	}
+}
+
+func TestSprintWordDiff(t *testing.T) {
+	a := []string{"the quick brown fox", "jumps over", "the lazy dog"}
+	b := []string{"the slow brown fox", "jumps over", "the dog", "today"}
+	diff := New(a, b)
+	diff.Compose()
+	uniHunks := diff.UnifiedHunks()
+
+	tests := []struct {
+		name     string
+		opts     WordDiffOptions
+		expected string
+	}{
+		{
+			name: "plain",
+			opts: WordDiffOptions{},
+			expected: `@@ -1,3 +1,4 @@
+the [-quick-]{+slow+} brown fox
+jumps over
+the [-lazy -]dog
+{+today+}
+`,
+		},
+		{
+			name: "porcelain",
+			opts: WordDiffOptions{Mode: WordDiffPorcelain},
+			expected: "@@ -1,3 +1,4 @@\n" +
+				" the \n" +
+				"-quick\n" +
+				"+slow\n" +
+				"  brown fox\n" +
+				"~\n" +
+				" jumps over\n" +
+				"~\n" +
+				" the \n" +
+				"-lazy \n" +
+				" dog\n" +
+				"~\n" +
+				"+today\n" +
+				"~\n",
+		},
+		{
+			name: "color",
+			opts: WordDiffOptions{Mode: WordDiffColor},
+			expected: "\x1b[36m@@ -1,3 +1,4 @@\x1b[m\n" +
+				"the \x1b[31mquick\x1b[m\x1b[32mslow\x1b[m brown fox\n" +
+				"jumps over\n" +
+				"the \x1b[31mlazy \x1b[mdog\n" +
+				"\x1b[32mtoday\x1b[m\n",
+		},
+	}
+
+	for _, tt := range tests {
+		if actual := SprintWordDiff(uniHunks, tt.opts); actual != tt.expected {
+			t.Fatalf(":%s: want: %q, actual: %q", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestNewWordDiff(t *testing.T) {
+	diff := NewWordDiff("a b c", "a c")
+	diff.Compose()
+	if diff.EditDistance() != 2 {
+		t.Fatalf("want: %v, actual: %v", 2, diff.EditDistance())
+	}
+}